@@ -0,0 +1,154 @@
+// Package app provides the demo host shared by every demo under
+// internal/demos: it owns the g3n application/window, the root scene and
+// camera, and the registry of runnable demos.
+package app
+
+import (
+	"time"
+
+	"github.com/g3n/engine/app"
+	"github.com/g3n/engine/camera"
+	"github.com/g3n/engine/core"
+	"github.com/g3n/engine/gui"
+	"github.com/g3n/engine/renderer"
+	"github.com/g3n/engine/util/logger"
+)
+
+// IDemo is implemented by every runnable demo. Start is called once when
+// the demo is selected. FixedUpdate is then called zero or more times per
+// frame at a constant dt (see SetFixedTimestep) to advance simulation
+// state, followed by exactly one call to Update with alpha in [0,1]: how
+// far between the previous and current fixed-timestep state the frame
+// falls, for demos that interpolate rendered positions. Cleanup is called
+// once when another demo is selected or the application exits.
+type IDemo interface {
+	Start(a *App)
+	FixedUpdate(a *App, dt time.Duration)
+	Update(a *App, alpha float32)
+	Cleanup(a *App)
+}
+
+// DemoMap holds every registered demo keyed by its dotted name
+// (e.g. "universe.gravity_movement"). Demos register themselves from an
+// init() function in their own package.
+var DemoMap = map[string]IDemo{}
+
+// defaultFixedTimestep matches physics.Simulation's own default_dt.
+const defaultFixedTimestep = time.Second / 60
+
+// maxSubsteps bounds how many FixedUpdate calls a single frame may spend
+// catching up. Without this cap, a long stall (e.g. the window being
+// dragged) produces a huge accumulated delta that takes more than one
+// frame to simulate, which produces an even bigger delta next frame: the
+// "spiral of death". We'd rather drop time than spiral.
+const maxSubsteps = 5
+
+// App is the shared host for all demos: it owns the underlying g3n
+// application/window, the root scene graph, the active camera and a
+// logger, and drives whichever demo is currently selected.
+type App struct {
+	*app.Application
+
+	scene    *core.Node
+	gui      *gui.Root
+	camera   *camera.Camera
+	renderer *renderer.Renderer
+	log      *logger.Logger
+
+	current  IDemo
+	currName string
+
+	fixedDt     time.Duration
+	accumulator time.Duration
+}
+
+// Create creates the App, initializing the underlying window, scene and
+// default camera. It does not start the render loop; call Run for that.
+func Create() *App {
+	a := new(App)
+	a.Application = app.App(1920, 1080, "universe")
+	a.log = logger.New("UNIVERSE", nil)
+
+	a.fixedDt = defaultFixedTimestep
+
+	a.scene = core.NewNode()
+	a.camera = camera.New(1)
+	a.scene.Add(a.camera)
+
+	a.gui = gui.NewRoot(a.Gls(), a.Application)
+	a.renderer = renderer.NewRenderer(a.Gls())
+	err := a.renderer.AddDefaultShaders()
+	if err != nil {
+		panic(err)
+	}
+	a.renderer.SetScene(a.scene)
+	a.renderer.SetGui(a.gui)
+
+	return a
+}
+
+// SetFixedTimestep sets the constant dt passed to FixedUpdate. It must be
+// called before Run; changing it mid-run would invalidate whatever the
+// current demo has accumulated in its own interpolation state.
+func (a *App) SetFixedTimestep(dt time.Duration) {
+	a.fixedDt = dt
+}
+
+// Run starts the selected demo (if any) and enters the main render loop.
+func (a *App) Run() {
+	if a.current != nil {
+		a.current.Start(a)
+	}
+	a.Application.Run(func(renderer *renderer.Renderer, deltaTime time.Duration) {
+		if a.current == nil {
+			renderer.Render(a.camera)
+			return
+		}
+
+		a.accumulator += deltaTime
+		steps := 0
+		for a.accumulator >= a.fixedDt && steps < maxSubsteps {
+			a.current.FixedUpdate(a, a.fixedDt)
+			a.accumulator -= a.fixedDt
+			steps++
+		}
+		if steps == maxSubsteps {
+			a.accumulator = 0
+		}
+
+		alpha := float32(a.accumulator) / float32(a.fixedDt)
+		a.current.Update(a, alpha)
+		renderer.Render(a.camera)
+	})
+}
+
+// SetDemo switches the running demo to the one registered under name,
+// cleaning up the previously running demo first.
+func (a *App) SetDemo(name string) {
+	demo, ok := DemoMap[name]
+	if !ok {
+		a.log.Error("no demo registered as %q", name)
+		return
+	}
+	if a.current != nil {
+		a.current.Cleanup(a)
+	}
+	a.current = demo
+	a.currName = name
+	demo.Start(a)
+}
+
+// Scene returns the root node of the scene graph.
+func (a *App) Scene() *core.Node {
+	return a.scene
+}
+
+// Camera returns the application's active camera.
+func (a *App) Camera() *camera.Camera {
+	return a.camera
+}
+
+// Log returns the application logger.
+func (a *App) Log() *logger.Logger {
+	return a.log
+}
@@ -0,0 +1,183 @@
+// Package controller holds movement controllers shared across demos.
+package controller
+
+import (
+	"time"
+
+	"github.com/g3n/engine/math32"
+)
+
+// SphericalController walks a point across the surface of a sphere
+// centered on the origin, mirroring the player model in server/main.go:
+// gravity always pulls toward the center, ground contact clamps the
+// point back to the surface and kills the inward velocity component,
+// and prolonged diving transitions into swimming via a timer. Unlike
+// the server sketch (which only applies gravity along world-Y), gravity
+// here is always -Gravity*Up, so it stays correct anywhere on the
+// sphere rather than just near one pole.
+//
+// Forward is the controller's own notion of "facing direction", kept
+// tangent to the sphere: FixedUpdate re-projects it onto the new
+// tangent plane every step so that holding "move forward" traces a
+// great circle around the sphere instead of drifting off its surface.
+type SphericalController struct {
+	Radius  float32
+	Gravity float32
+
+	WalkSpeed  float32
+	SwimSpeed  float32
+	ClimbSpeed float32
+
+	MaxDiveTime time.Duration
+
+	Position math32.Vector3
+	Velocity math32.Vector3
+	Forward  math32.Vector3
+
+	Swimming bool
+	Diving   bool
+	Climbing bool
+	Grounded bool
+
+	diveTime time.Duration
+}
+
+// NewSphericalController creates a controller standing at the given
+// starting position on (or above) a sphere of the given radius, facing
+// along startForward (which is immediately projected onto the tangent
+// plane at startPosition).
+func NewSphericalController(radius, gravity float32, startPosition, startForward math32.Vector3) *SphericalController {
+	c := &SphericalController{
+		Radius:      radius,
+		Gravity:     gravity,
+		WalkSpeed:   5,
+		SwimSpeed:   2,
+		ClimbSpeed:  1.5,
+		MaxDiveTime: 30 * time.Second,
+		Position:    startPosition,
+		Forward:     startForward,
+	}
+	c.orthogonalizeForward()
+	return c
+}
+
+// Up is the outward surface normal at the controller's current
+// position - "world-Y" for a flat-ground controller, but here it
+// depends on where on the sphere the controller stands.
+func (c *SphericalController) Up() math32.Vector3 {
+	up := c.Position
+	up.Normalize()
+	return up
+}
+
+// Right is the tangent-plane vector completing the (Forward, Up, Right)
+// basis, for strafing and for orienting a third-person or FPS camera.
+func (c *SphericalController) Right() math32.Vector3 {
+	up := c.Up()
+	right := c.Forward
+	right.Cross(&up)
+	right.Normalize()
+	return right
+}
+
+// Move sets the controller's horizontal velocity from forward/strafe
+// input in [-1, 1], scaled by the speed appropriate to the current
+// state (walking, swimming or climbing), mirroring the server's
+// updatePlayerMovement.
+func (c *SphericalController) Move(forwardAmount, strafeAmount float32) {
+	speed := c.WalkSpeed
+	switch {
+	case c.Swimming:
+		speed = c.SwimSpeed
+	case c.Climbing:
+		speed = c.ClimbSpeed
+	}
+
+	forward := c.Forward
+	forward.MultiplyScalar(forwardAmount)
+	right := c.Right()
+	right.MultiplyScalar(strafeAmount)
+
+	horizontal := forward
+	horizontal.Add(&right)
+	if horizontal.LengthSq() > 1 {
+		horizontal.Normalize()
+	}
+	horizontal.MultiplyScalar(speed)
+
+	// Preserve whatever radial (climbing/falling) velocity component was
+	// already present; Move only drives the tangent-plane component.
+	up := c.Up()
+	radial := up
+	radial.MultiplyScalar(up.Dot(&c.Velocity))
+	c.Velocity = horizontal
+	c.Velocity.Add(&radial)
+}
+
+// FixedUpdate advances the controller by dt: applies gravity toward the
+// sphere's center unless swimming, integrates position, resolves ground
+// contact, re-orients Forward to stay tangent to the surface, and
+// advances the dive timer.
+func (c *SphericalController) FixedUpdate(dt time.Duration) {
+	seconds := float32(dt.Seconds())
+
+	if !c.Swimming {
+		gravity := c.Up()
+		gravity.MultiplyScalar(-c.Gravity * seconds)
+		c.Velocity.Add(&gravity)
+	}
+
+	delta := c.Velocity
+	delta.MultiplyScalar(seconds)
+	c.Position.Add(&delta)
+
+	c.resolveGroundContact()
+	c.orthogonalizeForward()
+
+	if c.Diving {
+		c.diveTime += dt
+		if c.diveTime >= c.MaxDiveTime {
+			c.Diving = false
+			c.Swimming = true
+		}
+	} else {
+		c.diveTime = 0
+	}
+}
+
+// resolveGroundContact projects the controller back onto the sphere's
+// surface if it has sunk below radius R, and zeros the inward component
+// of its velocity so it doesn't keep pushing through the ground.
+func (c *SphericalController) resolveGroundContact() {
+	dist := c.Position.Length()
+	if dist >= c.Radius {
+		c.Grounded = false
+		return
+	}
+
+	c.Position.MultiplyScalar(c.Radius / dist)
+	c.Grounded = true
+
+	up := c.Up()
+	radial := up.Dot(&c.Velocity)
+	if radial < 0 {
+		correction := up
+		correction.MultiplyScalar(-radial)
+		c.Velocity.Add(&correction)
+	}
+}
+
+// orthogonalizeForward re-projects Forward onto the tangent plane at
+// the controller's current (possibly just-updated) position.
+func (c *SphericalController) orthogonalizeForward() {
+	up := c.Up()
+	f := c.Forward
+	proj := up
+	proj.MultiplyScalar(up.Dot(&f))
+	f.Sub(&proj)
+	if f.LengthSq() < 1e-10 {
+		return // Forward was parallel to Up; leave it as-is rather than divide by ~0.
+	}
+	f.Normalize()
+	c.Forward = f
+}
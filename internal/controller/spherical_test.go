@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/g3n/engine/math32"
+)
+
+func TestGravityPullsGroundedControllerBackToSurface(t *testing.T) {
+	start := *math32.NewVector3(10, 0, 0)
+	forward := *math32.NewVector3(0, 0, 1)
+	c := NewSphericalController(10, 9.8, start, forward)
+
+	for i := 0; i < 120; i++ {
+		c.FixedUpdate(16 * time.Millisecond)
+	}
+
+	if !c.Grounded {
+		t.Fatal("Grounded = false after settling under gravity")
+	}
+	if dist := c.Position.Length(); math32.Abs(dist-c.Radius) > 1e-3 {
+		t.Fatalf("Position.Length() = %v; want %v (clamped to Radius)", dist, c.Radius)
+	}
+	up := c.Up()
+	if radial := up.Dot(&c.Velocity); radial < -1e-3 {
+		t.Fatalf("inward velocity component = %v; want >= 0 once grounded", radial)
+	}
+}
+
+func TestForwardStaysTangentToSurface(t *testing.T) {
+	start := *math32.NewVector3(10, 0, 0)
+	forward := *math32.NewVector3(0, 0, 1)
+	c := NewSphericalController(10, 0, start, forward)
+
+	for i := 0; i < 50; i++ {
+		c.Move(1, 0)
+		c.FixedUpdate(16 * time.Millisecond)
+	}
+
+	up := c.Up()
+	if dot := up.Dot(&c.Forward); math32.Abs(dot) > 1e-3 {
+		t.Fatalf("Forward.Dot(Up) = %v; want ~0 (Forward must stay tangent)", dot)
+	}
+	if math32.Abs(c.Forward.Length()-1) > 1e-3 {
+		t.Fatalf("Forward.Length() = %v; want ~1", c.Forward.Length())
+	}
+}
+
+func TestWalkingForwardTracesGreatCircle(t *testing.T) {
+	// Gravity, not just the ground-contact clamp, is what keeps a walker
+	// pinned to the surface: each step's tangent move carries it
+	// slightly above the sphere, and gravity pulls it back down before
+	// the next one. With Gravity == 0 (see TestForwardStaysTangentToSurface)
+	// that correction doesn't happen and drift is expected.
+	start := *math32.NewVector3(10, 0, 0)
+	forward := *math32.NewVector3(0, 0, 1)
+	c := NewSphericalController(10, 9.8, start, forward)
+	c.WalkSpeed = 10
+
+	for i := 0; i < 200; i++ {
+		c.Move(1, 0)
+		c.FixedUpdate(16 * time.Millisecond)
+
+		if dist := c.Position.Length(); math32.Abs(dist-c.Radius) > 0.5 {
+			t.Fatalf("step %d: Position.Length() = %v; want within 0.5 of %v (should stay near the sphere)", i, dist, c.Radius)
+		}
+	}
+}
+
+func TestDiveTimerTransitionsToSwimming(t *testing.T) {
+	c := NewSphericalController(10, 0, *math32.NewVector3(10, 0, 0), *math32.NewVector3(0, 0, 1))
+	c.Diving = true
+	c.MaxDiveTime = 100 * time.Millisecond
+
+	c.FixedUpdate(60 * time.Millisecond)
+	if !c.Diving || c.Swimming {
+		t.Fatal("transitioned to swimming before MaxDiveTime elapsed")
+	}
+
+	c.FixedUpdate(60 * time.Millisecond)
+	if c.Diving || !c.Swimming {
+		t.Fatal("did not transition to swimming after MaxDiveTime elapsed")
+	}
+}
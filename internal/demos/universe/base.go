@@ -16,7 +16,11 @@ var (
 	currentDelta = 0 * time.Millisecond
 )
 
-// Get Random float32 within given range
-func getRandomFloat32(min, max float32) float32 {
-	return min + rand.Float32()*(max-min)
+// Get Random float32 within given range, drawn from rng. Demos that need
+// reproducible runs (e.g. for replay.Diff regression tests) should pass
+// their own *rand.Rand seeded from a known value rather than relying on
+// math/rand's global source, which is shared - and in newer Go versions
+// auto-seeded - process-wide.
+func getRandomFloat32(rng *rand.Rand, min, max float32) float32 {
+	return min + rng.Float32()*(max-min)
 }
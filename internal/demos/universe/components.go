@@ -0,0 +1,41 @@
+package universe
+
+import (
+	"github.com/g3n/engine/experimental/physics"
+	"github.com/g3n/engine/experimental/physics/object"
+	"github.com/g3n/engine/graphic"
+	"github.com/g3n/engine/math32"
+)
+
+// Mesh is the renderable component carried by every sphere entity; tick
+// and render systems read and write its position rather than reaching
+// into a package-level []*graphic.Mesh slice.
+type Mesh struct {
+	*graphic.Mesh
+}
+
+// Velocity is a per-tick displacement applied to an entity's Mesh.
+// RandomWalkSystem re-rolls it to a new random value every tick rather
+// than integrating it over time, so it reads as a jitter amount more
+// than a physical velocity.
+type Velocity struct {
+	math32.Vector3
+}
+
+// Body is the physics-engine side of a simulated sphere.
+type Body struct {
+	*object.Body
+}
+
+// AttractorField is the gravity source that follows its entity's Mesh
+// around, replacing the old map[*graphic.Mesh]*physics.AttractorForceField.
+type AttractorField struct {
+	*physics.AttractorForceField
+}
+
+// Interp holds the previous and current fixed-timestep physics positions
+// for a Mesh, letting InterpolationSystem render it at any alpha in
+// [0,1] between the two rather than snapping to the last physics step.
+type Interp struct {
+	Prev, Curr math32.Vector3
+}
@@ -1,19 +1,15 @@
 package universe
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/g3n/engine/experimental/physics"
-	"github.com/g3n/engine/experimental/physics/object"
-	"github.com/g3n/engine/geometry"
-	"github.com/g3n/engine/graphic"
-	"github.com/g3n/engine/material"
 	"github.com/g3n/engine/math32"
 	"github.com/g3n/engine/util/logger"
 	"github.com/g3n/engine/window"
 	"github.com/google/uuid"
 	"github.com/inux/universe/internal/app"
+	"github.com/inux/universe/internal/ecs"
 )
 
 func init() {
@@ -26,16 +22,16 @@ type UniverseSpheres struct {
 	sim *physics.Simulation
 	log *logger.Logger
 
-	meshes []*graphic.Mesh
-	fields map[*graphic.Mesh]*physics.AttractorForceField
+	world      *ecs.World
+	spawn      *SphereSpawnSystem
+	attractors AttractorFollowSystem
+	interp     InterpolationSystem
 }
 
 // Start is called once at the start of the demo.
 func (u *UniverseSpheres) Start(a *app.App) {
-	u.meshes = make([]*graphic.Mesh, 0)
-	u.fields = make(map[*graphic.Mesh]*physics.AttractorForceField, 0)
-
 	u.log = a.Log()
+	u.world = ecs.NewWorld()
 
 	// Unsubscribe events in case of reset to prevent duplicate events
 	a.UnsubscribeAllID(eventId)
@@ -49,36 +45,35 @@ func (u *UniverseSpheres) Start(a *app.App) {
 	u.sim = physics.NewSimulation(a.Scene())
 	u.sim.SetPaused(true)
 
-	geom := geometry.NewSphere(0.1, 20, 20)
-	mat := material.NewStandard(math32.NewColor("Black"))
+	u.spawn = &SphereSpawnSystem{Scene: a.Scene(), Sim: u.sim}
 
 	for z := 0.01; z < 5; z++ {
 		for y := 0.01; y < 5; y++ {
 			for x := 0.01; x < 5; x++ {
-				mesh := graphic.NewMesh(geom, mat)
-				pos := math32.NewVector3(float32(x), float32(y), float32(z))
-				mesh.SetPositionVec(pos)
-				a.Scene().Add(mesh)
-				u.sim.AddBody(object.NewBody(mesh), "sphere"+fmt.Sprintf("-%f-%f-%f", x, y, z))
-				u.meshes = append(u.meshes, mesh)
-				field := physics.NewAttractorForceField(pos, 1)
-				u.fields[mesh] = field
-				u.sim.AddForceField(field)
+				u.spawn.Spawn(*math32.NewVector3(float32(x), float32(y), float32(z)))
 			}
 		}
 	}
+	u.spawn.Tick(u.world, 0)
 }
 
-// Update is called every frame.
-func (u *UniverseSpheres) Update(a *app.App, deltaTime time.Duration) {
+// FixedUpdate advances the simulation by exactly dt, called an integer
+// number of times per frame by app.App's fixed-timestep driver.
+func (u *UniverseSpheres) FixedUpdate(a *app.App, dt time.Duration) {
+	u.interp.PreStep(u.world)
+
 	if !u.sim.Paused() {
-		u.sim.Step(float32(deltaTime.Seconds()))
+		u.sim.Step(float32(dt.Seconds()))
 	}
+	u.attractors.Tick(u.world, dt)
 
-	for _, mesh := range u.meshes {
-		pos := mesh.Position()
-		u.fields[mesh].SetPosition(&pos)
-	}
+	u.interp.PostStep(u.world)
+}
+
+// Update renders the current frame by interpolating every body between
+// its previous and current fixed-timestep position using alpha.
+func (u *UniverseSpheres) Update(a *app.App, alpha float32) {
+	u.interp.Render(u.world, alpha)
 }
 
 func (u *UniverseSpheres) onKey(evname string, ev interface{}) {
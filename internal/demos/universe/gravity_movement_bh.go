@@ -0,0 +1,120 @@
+package universe
+
+import (
+	"time"
+
+	"github.com/g3n/engine/geometry"
+	"github.com/g3n/engine/graphic"
+	"github.com/g3n/engine/material"
+	"github.com/g3n/engine/math32"
+	"github.com/g3n/engine/util/logger"
+	"github.com/g3n/engine/window"
+	"github.com/google/uuid"
+	"github.com/inux/universe/internal/app"
+	"github.com/inux/universe/internal/physics/nbody"
+)
+
+func init() {
+	app.DemoMap["universe.gravity_movement_bh"] = &BarnesHutSpheres{}
+}
+
+// bhGridSize is chosen so the grid holds more than 10k bodies, the scale
+// at which the O(N^2) physics.AttractorForceField approach used by
+// UniverseSpheres becomes too slow to simulate at interactive rates.
+const bhGridSize = 22
+
+var bhEventId = uuid.New()
+
+// BarnesHutSpheres renders the same grid-of-spheres-under-gravity demo
+// as UniverseSpheres, but drives it with an internal/physics/nbody
+// Simulation instead of g3n's physics.Simulation, trading exact O(N^2)
+// forces for a Barnes-Hut approximation so it scales to 10k+ bodies.
+// Bodies here aren't ecs.Entitys: nbody.Body carries no identity beyond
+// its slot in the Bodies slice, so meshes are tracked in a plain slice
+// indexed the same way.
+type BarnesHutSpheres struct {
+	log *logger.Logger
+
+	sim    *nbody.Simulation
+	meshes []*graphic.Mesh
+	prev   []math32.Vector3
+
+	paused bool
+}
+
+// Start is called once at the start of the demo.
+func (u *BarnesHutSpheres) Start(a *app.App) {
+	u.log = a.Log()
+	u.paused = true
+
+	// Unsubscribe events in case of reset to prevent duplicate events
+	a.UnsubscribeAllID(bhEventId)
+	a.SubscribeID(window.OnKeyRepeat, bhEventId, u.onKey)
+	a.SubscribeID(window.OnKeyDown, bhEventId, u.onKey)
+
+	a.Camera().SetPosition(5.25, 6.45, 9.31)
+	a.Camera().SetQuaternion(-0.25, 0.25, 0.06, 0.94)
+
+	geom := geometry.NewSphere(0.1, 8, 8)
+	mat := material.NewStandard(math32.NewColor("Black"))
+
+	var bodies []nbody.Body
+	for z := 0.01; z < bhGridSize; z++ {
+		for y := 0.01; y < bhGridSize; y++ {
+			for x := 0.01; x < bhGridSize; x++ {
+				pos := *math32.NewVector3(float32(x), float32(y), float32(z))
+				bodies = append(bodies, nbody.Body{Position: pos, Mass: 1})
+
+				mesh := graphic.NewMesh(geom, mat)
+				mesh.SetPositionVec(&pos)
+				a.Scene().Add(mesh)
+				u.meshes = append(u.meshes, mesh)
+			}
+		}
+	}
+
+	u.sim = nbody.NewSimulation(bodies, 0.5, 0.1, 1)
+	u.prev = make([]math32.Vector3, len(bodies))
+	u.copyPositions(u.prev)
+}
+
+// FixedUpdate advances the simulation by exactly dt, called an integer
+// number of times per frame by app.App's fixed-timestep driver.
+func (u *BarnesHutSpheres) FixedUpdate(a *app.App, dt time.Duration) {
+	u.copyPositions(u.prev)
+	if !u.paused {
+		u.sim.Step(float32(dt.Seconds()))
+	}
+}
+
+// Update renders the current frame by interpolating every body between
+// its previous and current fixed-timestep position using alpha.
+func (u *BarnesHutSpheres) Update(a *app.App, alpha float32) {
+	for i, mesh := range u.meshes {
+		pos := u.prev[i]
+		pos.Lerp(&u.sim.Bodies[i].Position, alpha)
+		mesh.SetPositionVec(&pos)
+	}
+}
+
+func (u *BarnesHutSpheres) copyPositions(dst []math32.Vector3) {
+	for i, b := range u.sim.Bodies {
+		dst[i] = b.Position
+	}
+}
+
+func (u *BarnesHutSpheres) onKey(evname string, ev interface{}) {
+	kev := ev.(*window.KeyEvent)
+	u.log.Debug("Key: ", kev.Key)
+	switch kev.Key {
+	case window.KeyP:
+		u.paused = !u.paused
+	case window.KeyO:
+		u.paused = false
+		u.sim.Step(0.016)
+		u.paused = true
+	}
+}
+
+// Cleanup is called once at the end of the demo.
+func (u *BarnesHutSpheres) Cleanup(a *app.App) {}
@@ -1,13 +1,11 @@
 package universe
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/g3n/engine/camera"
 	"github.com/g3n/engine/core"
 	"github.com/g3n/engine/experimental/physics"
-	"github.com/g3n/engine/experimental/physics/object"
 	"github.com/g3n/engine/geometry"
 	"github.com/g3n/engine/graphic"
 	"github.com/g3n/engine/material"
@@ -16,22 +14,35 @@ import (
 	"github.com/g3n/engine/util/logger"
 	"github.com/g3n/engine/window"
 	"github.com/inux/universe/internal/app"
+	"github.com/inux/universe/internal/ecs"
+	"github.com/inux/universe/internal/replay"
 )
 
 func init() {
 	app.DemoMap["universe.gravity_movement_editor"] = &UniverseSpheresEditor{}
 }
 
+// replayPath is where the R/Y/U key bindings below save and load
+// recordings. A future settings UI could let this vary per-recording.
+const replayPath = "recording.univ"
+
 type UniverseSpheresEditor struct {
 	log    *logger.Logger
 	scene  *core.Node
 	camera *camera.Camera
 
 	referenceMesh *graphic.Mesh
-	meshes        []*graphic.Mesh
-	fields        map[*graphic.Mesh]*physics.AttractorForceField
+
+	world      *ecs.World
+	spawn      *SphereSpawnSystem
+	attractors AttractorFollowSystem
+	interp     InterpolationSystem
 
 	sim *physics.Simulation
+
+	recorder  *replay.Recorder
+	recording bool
+	player    *replay.Player
 }
 
 // Start is called once at the start of the demo.
@@ -39,12 +50,11 @@ func (u *UniverseSpheresEditor) Start(a *app.App) {
 	u.log = a.Log()
 	u.scene = a.Scene()
 	u.camera = a.Camera()
+	u.world = ecs.NewWorld()
 
 	referenceSphereGeom := geometry.NewSphere(1.5, 16, 16)
 	referenceSphereMat := material.NewStandard(&math32.Color{R: 0.9, G: 0.6, B: 0.3})
 	u.referenceMesh = graphic.NewMesh(referenceSphereGeom, referenceSphereMat)
-	u.meshes = make([]*graphic.Mesh, 0)
-	u.fields = make(map[*graphic.Mesh]*physics.AttractorForceField, 0)
 
 	// Unsubscribe events in case of reset to prevent duplicate events
 	a.UnsubscribeAllID(eventId)
@@ -57,6 +67,7 @@ func (u *UniverseSpheresEditor) Start(a *app.App) {
 
 	u.sim = physics.NewSimulation(u.scene)
 	u.sim.SetPaused(true)
+	u.spawn = &SphereSpawnSystem{Scene: u.scene, Sim: u.sim}
 
 	u.referenceMesh.SetPosition(0, 0, 0)
 	u.scene.Add(u.referenceMesh)
@@ -64,16 +75,93 @@ func (u *UniverseSpheresEditor) Start(a *app.App) {
 	u.scene.Add(helper.NewAxes(1000))
 }
 
-// Update is called every frame.
-func (u *UniverseSpheresEditor) Update(a *app.App, deltaTime time.Duration) {
+// FixedUpdate advances the simulation by exactly dt, called an integer
+// number of times per frame by app.App's fixed-timestep driver.
+func (u *UniverseSpheresEditor) FixedUpdate(a *app.App, dt time.Duration) {
+	u.interp.PreStep(u.world)
+
 	if !u.sim.Paused() {
-		u.sim.Step(float32(deltaTime.Seconds()))
+		u.sim.Step(float32(dt.Seconds()))
+		if u.recording {
+			u.recorder.Record(u.captureFrame())
+		}
 	}
+	u.spawn.Tick(u.world, dt)
+	u.attractors.Tick(u.world, dt)
+
+	u.interp.PostStep(u.world)
+}
 
-	for _, mesh := range u.meshes {
-		pos := mesh.Position()
-		u.fields[mesh].SetPosition(&pos)
+// captureFrame snapshots every simulated body's current position and
+// velocity, keyed by the name it was registered under in u.sim.
+func (u *UniverseSpheresEditor) captureFrame() replay.Frame {
+	frame := make(replay.Frame)
+	ecs.Components[Body](u.world).Each(func(_ ecs.Entity, b *Body) {
+		frame[b.Name()] = replay.Snapshot{Position: b.Position(), Velocity: b.Velocity()}
+	})
+	return frame
+}
+
+// startRecording begins a new in-memory recording of every physics Step
+// from this point on, discarding any previous unsaved recording.
+func (u *UniverseSpheresEditor) startRecording() {
+	u.recorder = replay.NewRecorder(replay.Header{BodyCount: int32(ecs.Components[Body](u.world).Len())})
+	u.recording = true
+	u.log.Info("replay: recording started")
+}
+
+// stopRecording ends the current recording; Y saves it to disk.
+func (u *UniverseSpheresEditor) stopRecording() {
+	u.recording = false
+	u.log.Info("replay: recording stopped (%d frames)", u.recorder.Len())
+}
+
+func (u *UniverseSpheresEditor) saveRecording() {
+	if u.recorder == nil || u.recorder.Len() == 0 {
+		u.log.Warn("replay: nothing to save")
+		return
+	}
+	if err := replay.Save(replayPath, u.recorder.Recording()); err != nil {
+		u.log.Error("replay: save failed: %v", err)
+		return
+	}
+	u.log.Info("replay: saved %d frames to %s", u.recorder.Len(), replayPath)
+}
+
+func (u *UniverseSpheresEditor) loadRecording() {
+	rec, err := replay.Load(replayPath)
+	if err != nil {
+		u.log.Error("replay: load failed: %v", err)
+		return
 	}
+	u.player = replay.NewPlayer(rec)
+	u.log.Info("replay: loaded %d frames from %s; scrub with [ and ] while paused", len(rec.Frames), replayPath)
+}
+
+// scrub applies frame to every live body's mesh, for stepping through a
+// loaded replay while the simulation itself is paused. It also overwrites
+// the body's Interp snapshot so the next FixedUpdate's PreStep doesn't
+// stomp the scrubbed position back to whatever it was before scrubbing.
+func (u *UniverseSpheresEditor) scrub(frame replay.Frame) {
+	ecs.Components[Body](u.world).Each(func(e ecs.Entity, b *Body) {
+		snap, ok := frame[b.Name()]
+		if !ok {
+			return
+		}
+		b.SetPositionVec(&snap.Position)
+
+		if interp, ok := ecs.GetComponent[Interp](u.world, e); ok {
+			interp.Prev = snap.Position
+			interp.Curr = snap.Position
+		}
+	})
+}
+
+// Update renders the current frame by interpolating every spawned sphere
+// between its previous and current fixed-timestep position using alpha.
+// The reference mesh itself isn't simulated, so it needs no interpolation.
+func (u *UniverseSpheresEditor) Update(a *app.App, alpha float32) {
+	u.interp.Render(u.world, alpha)
 }
 
 func (u *UniverseSpheresEditor) onKeyDown(evname string, ev interface{}) {
@@ -99,24 +187,29 @@ func (u *UniverseSpheresEditor) onKeyDown(evname string, ev interface{}) {
 	case window.KeyK:
 		u.referenceMesh.TranslateY(-1)
 	case window.KeySpace:
-		u.addNewSphere()
+		u.spawn.Spawn(u.referenceMesh.Position())
+	case window.KeyR:
+		if u.recording {
+			u.stopRecording()
+		} else {
+			u.startRecording()
+		}
+	case window.KeyY:
+		u.saveRecording()
+	case window.KeyU:
+		u.loadRecording()
+	case window.KeyLeftBracket:
+		if u.player != nil && u.sim.Paused() {
+			u.scrub(u.player.StepBackward())
+		}
+	case window.KeyRightBracket:
+		if u.player != nil && u.sim.Paused() {
+			u.scrub(u.player.StepForward())
+		}
 	}
 }
 
-func (u *UniverseSpheresEditor) addNewSphere() {
-	geom := geometry.NewSphere(0.1, 20, 20)
-	mat := material.NewStandard(math32.NewColor("Black"))
-
-	mesh := graphic.NewMesh(geom, mat)
-	pos := u.referenceMesh.Position()
-	mesh.SetPositionVec(&pos)
-	u.scene.Add(mesh)
-	u.meshes = append(u.meshes, mesh)
-	u.sim.AddBody(object.NewBody(mesh), "sphere"+fmt.Sprintf("-%f-%f-%f", pos.X, pos.Y, pos.Z))
-	field := physics.NewAttractorForceField(&pos, 1)
-	u.fields[mesh] = field
-	u.sim.AddForceField(field)
-}
+func (u *UniverseSpheresEditor) onKeyUp(evname string, ev interface{}) {}
 
 // Cleanup is called once at the end of the demo.
 func (u *UniverseSpheresEditor) Cleanup(a *app.App) {}
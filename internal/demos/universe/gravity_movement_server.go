@@ -0,0 +1,254 @@
+package universe
+
+import (
+	stdnet "net"
+	"net/http"
+	"time"
+
+	"github.com/g3n/engine/geometry"
+	"github.com/g3n/engine/graphic"
+	"github.com/g3n/engine/material"
+	"github.com/g3n/engine/math32"
+	"github.com/g3n/engine/util/logger"
+	"github.com/g3n/engine/window"
+	"github.com/inux/universe/internal/app"
+	"github.com/inux/universe/internal/net"
+)
+
+func init() {
+	app.DemoMap["universe.gravity_movement_server"] = &HostedSpheres{}
+}
+
+// localClientID is the session name HostedSpheres registers as when it
+// connects to its own hosted net.Server; any other WebSocket client could
+// connect to the same listener under a different id and see the same
+// world.
+const localClientID = "local"
+
+// HostedSpheres runs an authoritative net.Server in the background behind
+// a real WebSocket listener and connects to it exactly like any other
+// client would, demonstrating that the client is just one more
+// (interpolated) spectator of the networked simulation rather than the
+// thing driving it. Every sphere is a remote entity from the client's
+// point of view, so they're all rendered through a net.RemoteBuffer
+// rather than snapped straight to the latest snapshot; the reference
+// mesh is the one body this client controls, so it's driven through a
+// net.PredictedBody instead, predicting each key press locally and
+// reconciling against the snapshots echoed back over the socket.
+type HostedSpheres struct {
+	log *logger.Logger
+
+	// Prediction tunes the client-side prediction and entity
+	// interpolation described by net.PredictedBody and net.RemoteBuffer.
+	// Exposed on the demo so it can be overridden before Start, e.g. in
+	// tests or by a future settings UI.
+	Prediction net.PredictionConfig
+
+	server   *net.Server
+	listener stdnet.Listener
+	client   *net.Client
+	stop     chan struct{}
+	states   chan net.WorldState
+	paused   bool
+
+	referenceMesh *graphic.Mesh
+	predicted     *net.PredictedBody
+
+	meshes  map[string]*graphic.Mesh
+	remotes map[string]*net.RemoteBuffer
+}
+
+// Start is called once at the start of the demo.
+func (h *HostedSpheres) Start(a *app.App) {
+	h.log = a.Log()
+	h.meshes = make(map[string]*graphic.Mesh)
+	h.remotes = make(map[string]*net.RemoteBuffer)
+	if h.Prediction == (net.PredictionConfig{}) {
+		h.Prediction = net.DefaultPredictionConfig()
+	}
+
+	h.server = net.NewServer()
+	h.stop = make(chan struct{})
+	h.states = make(chan net.WorldState, 8)
+
+	var err error
+	h.listener, err = stdnet.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		h.log.Error("hosted server: listen failed: %v", err)
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", h.server.ServeWS)
+	go http.Serve(h.listener, mux)
+
+	h.client, err = net.Dial("ws://" + h.listener.Addr().String() + "/ws?id=" + localClientID)
+	if err != nil {
+		h.log.Error("hosted server: dial failed: %v", err)
+		return
+	}
+	go h.readLoop()
+	go h.server.Run(h.stop)
+
+	h.referenceMesh = h.newReferenceMesh()
+	a.Scene().Add(h.referenceMesh)
+	h.predicted = net.NewPredictedBody(h.Prediction, h.referenceMesh.Position())
+
+	// Unsubscribe events in case of reset to prevent duplicate events
+	a.UnsubscribeAllID(eventId)
+	a.SubscribeID(window.OnKeyDown, eventId, h.onKeyDown)
+
+	h.send(net.ClientMessage{Type: net.MsgResume})
+	for i := 0; i < 5; i++ {
+		h.send(net.ClientMessage{Type: net.MsgSpawnSphere})
+	}
+
+	a.Camera().SetPosition(-45, 75, -25)
+	a.Camera().SetQuaternion(0.15, 0.85, 0.35, -0.35)
+}
+
+// readLoop forwards every WorldState the server broadcasts to this client
+// into h.states, dropping the oldest buffered state rather than blocking
+// if FixedUpdate hasn't drained it yet.
+func (h *HostedSpheres) readLoop() {
+	for {
+		state, err := h.client.Recv()
+		if err != nil {
+			return
+		}
+		select {
+		case h.states <- state:
+		default:
+			<-h.states
+			h.states <- state
+		}
+	}
+}
+
+// send writes msg to the server over the WebSocket connection, logging
+// (rather than panicking on) a failure so a dropped connection doesn't
+// take down the demo.
+func (h *HostedSpheres) send(msg net.ClientMessage) {
+	if err := h.client.Send(msg); err != nil {
+		h.log.Error("hosted server: send failed: %v", err)
+	}
+}
+
+// FixedUpdate drains whatever snapshots the server has broadcast since
+// the last fixed step into each body's RemoteBuffer.
+func (h *HostedSpheres) FixedUpdate(a *app.App, dt time.Duration) {
+	for {
+		select {
+		case state := <-h.states:
+			h.absorb(a, state)
+		default:
+			return
+		}
+	}
+}
+
+// Update renders every mesh at its RemoteBuffer's interpolated (or
+// briefly extrapolated) pose. Remote entities are smoothed against wall
+// clock time rather than alpha, since the server's send rate is
+// independent of this client's fixed timestep. The reference mesh is
+// already up to date as of the last absorbed snapshot and reconciled
+// input, so it needs no further interpolation here.
+func (h *HostedSpheres) Update(a *app.App, alpha float32) {
+	now := time.Now()
+	for name, buf := range h.remotes {
+		mesh, ok := h.meshes[name]
+		if !ok {
+			continue
+		}
+		render := buf.StateAt(now)
+		mesh.SetPositionVec(&render.Position)
+		mesh.SetQuaternionQuat(&render.Orientation)
+	}
+}
+
+func (h *HostedSpheres) absorb(a *app.App, state net.WorldState) {
+	now := time.Now()
+	h.paused = state.Paused
+
+	h.predicted.Reconcile(state.Reference, state.LastSequence[localClientID])
+	h.referenceMesh.SetPositionVec(&h.predicted.Position)
+
+	for _, body := range state.Bodies {
+		mesh, ok := h.meshes[body.Name]
+		if !ok {
+			mesh = h.newSphereMesh()
+			a.Scene().Add(mesh)
+			h.meshes[body.Name] = mesh
+			h.remotes[body.Name] = net.NewRemoteBuffer(h.Prediction)
+		}
+		h.remotes[body.Name].Push(now, body.Position, body.Velocity, body.Orientation)
+	}
+}
+
+func (h *HostedSpheres) newReferenceMesh() *graphic.Mesh {
+	geom := geometry.NewSphere(1.5, 16, 16)
+	mat := material.NewStandard(&math32.Color{R: 0.9, G: 0.6, B: 0.3})
+	mesh := graphic.NewMesh(geom, mat)
+	mesh.SetPosition(0, 0, 0)
+	return mesh
+}
+
+func (h *HostedSpheres) newSphereMesh() *graphic.Mesh {
+	geom := geometry.NewSphere(0.1, 20, 20)
+	mat := material.NewStandard(math32.NewColor("Black"))
+	return graphic.NewMesh(geom, mat)
+}
+
+func (h *HostedSpheres) onKeyDown(evname string, ev interface{}) {
+	kev := ev.(*window.KeyEvent)
+	h.log.Debug("Key: ", kev.Key)
+	switch kev.Key {
+	case window.KeyP:
+		if h.paused {
+			h.send(net.ClientMessage{Type: net.MsgResume})
+		} else {
+			h.send(net.ClientMessage{Type: net.MsgPause})
+		}
+	case window.KeyO:
+		h.send(net.ClientMessage{Type: net.MsgStep})
+	case window.KeySpace:
+		h.send(net.ClientMessage{Type: net.MsgSpawnSphere})
+	case window.KeyW:
+		h.moveReference(math32.Vector3{Z: 1})
+	case window.KeyS:
+		h.moveReference(math32.Vector3{Z: -1})
+	case window.KeyA:
+		h.moveReference(math32.Vector3{X: 1})
+	case window.KeyD:
+		h.moveReference(math32.Vector3{X: -1})
+	case window.KeyI:
+		h.moveReference(math32.Vector3{Y: 1})
+	case window.KeyK:
+		h.moveReference(math32.Vector3{Y: -1})
+	}
+	// net.MsgSetAttractorMass has no binding here: UniverseSpheresEditor,
+	// the demo this one mirrors key-for-key, has no mass-adjustment key
+	// of its own to mirror. It's reachable today only from another
+	// client connecting to the same hosted net.Server.
+}
+
+// moveReference predicts delta locally, so the key feels instant despite
+// going over the socket, and forwards the same delta to the server as a
+// sequenced ClientMessage so the next snapshot's reconciliation can
+// correct for anything the server processed differently (e.g. a second
+// client also moving the reference).
+func (h *HostedSpheres) moveReference(delta math32.Vector3) {
+	seq := h.predicted.ApplyInput(delta, time.Second)
+	h.referenceMesh.SetPositionVec(&h.predicted.Position)
+	h.send(net.ClientMessage{Type: net.MsgMoveReference, Sequence: seq, Move: delta})
+}
+
+// Cleanup is called once at the end of the demo.
+func (h *HostedSpheres) Cleanup(a *app.App) {
+	close(h.stop)
+	if h.client != nil {
+		h.client.Close()
+	}
+	if h.listener != nil {
+		h.listener.Close()
+	}
+}
@@ -1,6 +1,7 @@
 package universe
 
 import (
+	"math/rand"
 	"time"
 
 	"github.com/g3n/engine/geometry"
@@ -8,6 +9,7 @@ import (
 	"github.com/g3n/engine/material"
 	"github.com/g3n/engine/math32"
 	"github.com/inux/universe/internal/app"
+	"github.com/inux/universe/internal/ecs"
 )
 
 func init() {
@@ -15,12 +17,19 @@ func init() {
 }
 
 type RandomSpheres struct {
-	meshes []*graphic.Mesh
+	// Seed drives the demo's random walk. Left at zero it's set to a
+	// fixed default on Start so the demo is reproducible out of the
+	// box; override it before Start for a different run.
+	Seed int64
+
+	world  *ecs.World
+	walker RandomWalkSystem
 }
 
 // Start is called once at the start of the demo.
 func (rs *RandomSpheres) Start(a *app.App) {
-	rs.meshes = make([]*graphic.Mesh, 0)
+	rs.world = ecs.NewWorld()
+	rs.walker = RandomWalkSystem{Rng: rand.New(rand.NewSource(rs.Seed))}
 
 	geom := geometry.NewSphere(0.1, 20, 20)
 	mat := material.NewStandard(math32.NewColor("Black"))
@@ -32,27 +41,28 @@ func (rs *RandomSpheres) Start(a *app.App) {
 				mesh := graphic.NewMesh(geom, mat)
 				mesh.SetPositionVec(math32.NewVector3(float32(x), float32(y), float32(z)))
 				a.Scene().Add(mesh)
-				rs.meshes = append(rs.meshes, mesh)
+
+				e := rs.world.NewEntity()
+				ecs.AddComponent(rs.world, e, Mesh{mesh})
+				ecs.AddComponent(rs.world, e, Velocity{})
 			}
 		}
 	}
 }
 
-// Update is called every frame.
-func (rs *RandomSpheres) Update(a *app.App, deltaTime time.Duration) {
-
-	currentDelta += deltaTime
+// FixedUpdate is called an integer number of times per frame by app.App's
+// fixed-timestep driver.
+func (rs *RandomSpheres) FixedUpdate(a *app.App, dt time.Duration) {
+	currentDelta += dt
 	if currentDelta > minTime {
-		for _, mesh := range rs.meshes {
-			pos := mesh.Position()
-			pos.X += getRandomFloat32(-0.5, 0.5)
-			pos.Y += getRandomFloat32(-0.5, 0.5)
-			pos.Z += getRandomFloat32(-0.5, 0.5)
-			mesh.SetPositionVec(&pos)
-		}
+		rs.walker.Tick(rs.world, dt)
 		currentDelta = 0
 	}
 }
 
+// Update is called once per frame; RandomSpheres has nothing to
+// interpolate, so it's a no-op.
+func (rs *RandomSpheres) Update(a *app.App, alpha float32) {}
+
 // Cleanup is called once at the end of the demo.
 func (rs *RandomSpheres) Cleanup(a *app.App) {}
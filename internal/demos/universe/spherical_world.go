@@ -0,0 +1,160 @@
+package universe
+
+import (
+	"time"
+
+	"github.com/g3n/engine/camera"
+	"github.com/g3n/engine/geometry"
+	"github.com/g3n/engine/graphic"
+	"github.com/g3n/engine/material"
+	"github.com/g3n/engine/math32"
+	"github.com/g3n/engine/util/logger"
+	"github.com/g3n/engine/window"
+	"github.com/google/uuid"
+	"github.com/inux/universe/internal/app"
+	"github.com/inux/universe/internal/controller"
+)
+
+func init() {
+	app.DemoMap["universe.spherical_world"] = &SphericalWorld{}
+}
+
+var sphericalEventId = uuid.New()
+
+// sphericalWorldRadius stands in for server/main.go's EARTH_RADIUS: the
+// server models an actual planet in meters, which is a fine unit for a
+// headless physics sketch but unworkable for a scene the camera can
+// actually see, so the demo uses a small radius instead.
+const (
+	sphericalWorldRadius  = 20
+	sphericalWorldGravity = 9.8
+
+	cameraFollowDistance = 8
+	cameraFollowHeight   = 3
+)
+
+// SphericalWorld ports the player model in server/main.go - walking on
+// a sphere with gravity toward the center - into a client demo, using a
+// controller.SphericalController instead of the server's Y-only gravity
+// and untouched (never-zeroed) ground velocity.
+type SphericalWorld struct {
+	log *logger.Logger
+
+	camera *camera.Camera
+	player *graphic.Mesh
+
+	ctl *controller.SphericalController
+
+	moveForward, moveBack, moveLeft, moveRight bool
+}
+
+// Start is called once at the start of the demo.
+func (s *SphericalWorld) Start(a *app.App) {
+	s.log = a.Log()
+	s.camera = a.Camera()
+
+	a.UnsubscribeAllID(sphericalEventId)
+	a.SubscribeID(window.OnKeyDown, sphericalEventId, s.onKeyDown)
+	a.SubscribeID(window.OnKeyUp, sphericalEventId, s.onKeyUp)
+
+	worldGeom := geometry.NewSphere(sphericalWorldRadius, 32, 32)
+	worldMat := material.NewStandard(math32.NewColor("SteelBlue"))
+	world := graphic.NewMesh(worldGeom, worldMat)
+	a.Scene().Add(world)
+
+	startPos := *math32.NewVector3(0, sphericalWorldRadius, 0)
+	startForward := *math32.NewVector3(0, 0, 1)
+	s.ctl = controller.NewSphericalController(sphericalWorldRadius, sphericalWorldGravity, startPos, startForward)
+
+	playerGeom := geometry.NewSphere(0.5, 16, 16)
+	playerMat := material.NewStandard(math32.NewColor("Black"))
+	s.player = graphic.NewMesh(playerGeom, playerMat)
+	s.player.SetPositionVec(&s.ctl.Position)
+	a.Scene().Add(s.player)
+
+	s.updateCamera()
+}
+
+// FixedUpdate advances the controller by exactly dt, called an integer
+// number of times per frame by app.App's fixed-timestep driver.
+func (s *SphericalWorld) FixedUpdate(a *app.App, dt time.Duration) {
+	var forwardAmount, strafeAmount float32
+	if s.moveForward {
+		forwardAmount++
+	}
+	if s.moveBack {
+		forwardAmount--
+	}
+	if s.moveRight {
+		strafeAmount++
+	}
+	if s.moveLeft {
+		strafeAmount--
+	}
+
+	s.ctl.Move(forwardAmount, strafeAmount)
+	s.ctl.FixedUpdate(dt)
+	s.player.SetPositionVec(&s.ctl.Position)
+}
+
+// Update re-renders the player and re-orients the camera every frame so
+// it follows from behind and above along the controller's own (tangent,
+// re-oriented-every-step) basis rather than a fixed world-space offset.
+func (s *SphericalWorld) Update(a *app.App, alpha float32) {
+	s.updateCamera()
+}
+
+func (s *SphericalWorld) updateCamera() {
+	up := s.ctl.Up()
+
+	eye := up
+	eye.MultiplyScalar(cameraFollowHeight)
+	behind := s.ctl.Forward
+	behind.MultiplyScalar(-cameraFollowDistance)
+	eye.Add(&behind)
+	eye.Add(&s.ctl.Position)
+
+	s.camera.SetPositionVec(&eye)
+	s.camera.LookAt(&s.ctl.Position, &up)
+}
+
+func (s *SphericalWorld) onKeyDown(evname string, ev interface{}) {
+	kev := ev.(*window.KeyEvent)
+	s.log.Debug("Key: ", kev.Key)
+	switch kev.Key {
+	case window.KeyW:
+		s.moveForward = true
+	case window.KeyS:
+		s.moveBack = true
+	case window.KeyA:
+		s.moveLeft = true
+	case window.KeyD:
+		s.moveRight = true
+	case window.KeyC:
+		s.ctl.Climbing = !s.ctl.Climbing
+	case window.KeyV:
+		s.ctl.Swimming = !s.ctl.Swimming
+	case window.KeyB:
+		// Starts a dive; FixedUpdate flips Diving back off (and
+		// Swimming on) once MaxDiveTime elapses, mirroring the
+		// server's dive-timer transition.
+		s.ctl.Diving = true
+	}
+}
+
+func (s *SphericalWorld) onKeyUp(evname string, ev interface{}) {
+	kev := ev.(*window.KeyEvent)
+	switch kev.Key {
+	case window.KeyW:
+		s.moveForward = false
+	case window.KeyS:
+		s.moveBack = false
+	case window.KeyA:
+		s.moveLeft = false
+	case window.KeyD:
+		s.moveRight = false
+	}
+}
+
+// Cleanup is called once at the end of the demo.
+func (s *SphericalWorld) Cleanup(a *app.App) {}
@@ -0,0 +1,134 @@
+package universe
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/g3n/engine/core"
+	"github.com/g3n/engine/experimental/physics"
+	"github.com/g3n/engine/experimental/physics/object"
+	"github.com/g3n/engine/geometry"
+	"github.com/g3n/engine/graphic"
+	"github.com/g3n/engine/material"
+	"github.com/g3n/engine/math32"
+	"github.com/inux/universe/internal/ecs"
+)
+
+// RandomWalkSystem re-rolls every entity's Velocity each tick and applies
+// it to the entity's Mesh, replacing RandomSpheres' hand-rolled mesh loop.
+// Rng must be seeded by the caller so runs are reproducible.
+type RandomWalkSystem struct {
+	Rng *rand.Rand
+}
+
+// Tick implements ecs.TickSystem.
+func (s RandomWalkSystem) Tick(w *ecs.World, dt time.Duration) {
+	ecs.NewView2[Mesh, Velocity](w).ForEach(func(_ ecs.Entity, m *Mesh, v *Velocity) {
+		v.X = getRandomFloat32(s.Rng, -0.5, 0.5)
+		v.Y = getRandomFloat32(s.Rng, -0.5, 0.5)
+		v.Z = getRandomFloat32(s.Rng, -0.5, 0.5)
+
+		pos := m.Position()
+		pos.X += v.X
+		pos.Y += v.Y
+		pos.Z += v.Z
+		m.SetPositionVec(&pos)
+	})
+}
+
+// AttractorFollowSystem keeps every AttractorField positioned at its
+// entity's current Mesh location, replacing the per-frame
+// "for mesh := range u.meshes { u.fields[mesh].SetPosition(...) }" loop.
+type AttractorFollowSystem struct{}
+
+// Tick implements ecs.TickSystem.
+func (AttractorFollowSystem) Tick(w *ecs.World, dt time.Duration) {
+	ecs.NewView2[Mesh, AttractorField](w).ForEach(func(_ ecs.Entity, m *Mesh, f *AttractorField) {
+		pos := m.Position()
+		f.SetPosition(&pos)
+	})
+}
+
+// SphereSpawnSystem turns queued spawn requests (e.g. from a key binding)
+// into new sphere entities with Mesh, Body and AttractorField components,
+// registering each with the scene and the physics simulation.
+type SphereSpawnSystem struct {
+	Scene *core.Node
+	Sim   *physics.Simulation
+
+	pending []math32.Vector3
+}
+
+// Spawn queues a sphere to be created at pos on the next Tick.
+func (s *SphereSpawnSystem) Spawn(pos math32.Vector3) {
+	s.pending = append(s.pending, pos)
+}
+
+// Tick implements ecs.TickSystem.
+func (s *SphereSpawnSystem) Tick(w *ecs.World, dt time.Duration) {
+	for _, pos := range s.pending {
+		s.spawnAt(w, pos)
+	}
+	s.pending = s.pending[:0]
+}
+
+func (s *SphereSpawnSystem) spawnAt(w *ecs.World, pos math32.Vector3) {
+	geom := geometry.NewSphere(0.1, 20, 20)
+	mat := material.NewStandard(math32.NewColor("Black"))
+	mesh := graphic.NewMesh(geom, mat)
+	mesh.SetPositionVec(&pos)
+	s.Scene.Add(mesh)
+
+	e := w.NewEntity()
+	ecs.AddComponent(w, e, Mesh{mesh})
+
+	body := object.NewBody(mesh)
+	s.Sim.AddBody(body, fmt.Sprintf("sphere-%d", e.Index()))
+	ecs.AddComponent(w, e, Body{body})
+
+	field := physics.NewAttractorForceField(&pos, 1)
+	s.Sim.AddForceField(field)
+	ecs.AddComponent(w, e, AttractorField{field})
+
+	ecs.AddComponent(w, e, Interp{Prev: pos, Curr: pos})
+}
+
+// InterpolationSystem decouples each Mesh's rendered transform from the
+// physics-authoritative position the simulation advances, so demos can
+// render smoothly between fixed timesteps no matter how the frame rate
+// relates to the physics rate.
+//
+// The three methods are called in a strict order once per frame:
+// PreStep (undo last frame's render-only interpolation before physics
+// reads the mesh), then Step, then PostStep (capture the new
+// authoritative position) for every FixedUpdate substep, and finally
+// Render once with that frame's leftover alpha.
+type InterpolationSystem struct{}
+
+// PreStep restores every interpolated Mesh to its last captured
+// authoritative position. Without this, physics would advance from
+// whatever position Render last (temporarily) wrote for display.
+func (InterpolationSystem) PreStep(w *ecs.World) {
+	ecs.NewView2[Mesh, Interp](w).ForEach(func(_ ecs.Entity, m *Mesh, i *Interp) {
+		m.SetPositionVec(&i.Curr)
+	})
+}
+
+// PostStep captures the authoritative position physics just produced,
+// shifting the old Curr into Prev.
+func (InterpolationSystem) PostStep(w *ecs.World) {
+	ecs.NewView2[Mesh, Interp](w).ForEach(func(_ ecs.Entity, m *Mesh, i *Interp) {
+		i.Prev = i.Curr
+		i.Curr = m.Position()
+	})
+}
+
+// Render sets every interpolated Mesh to lerp(Prev, Curr, alpha), purely
+// for display; PreStep undoes this before the next physics step.
+func (InterpolationSystem) Render(w *ecs.World, alpha float32) {
+	ecs.NewView2[Mesh, Interp](w).ForEach(func(_ ecs.Entity, m *Mesh, i *Interp) {
+		pos := i.Prev
+		m.SetPositionVec(pos.Lerp(&i.Curr, alpha))
+	})
+}
@@ -0,0 +1,170 @@
+package ecs
+
+import "testing"
+
+type position struct{ X, Y, Z float32 }
+type velocity struct{ X, Y, Z float32 }
+
+func TestAddRemoveComponent(t *testing.T) {
+	w := NewWorld()
+	e := w.NewEntity()
+
+	AddComponent(w, e, position{1, 2, 3})
+	pos, ok := GetComponent[position](w, e)
+	if !ok || *pos != (position{1, 2, 3}) {
+		t.Fatalf("GetComponent = %v, %v; want {1 2 3}, true", pos, ok)
+	}
+
+	if !RemoveComponent[position](w, e) {
+		t.Fatal("RemoveComponent = false; want true")
+	}
+	if _, ok := GetComponent[position](w, e); ok {
+		t.Fatal("component still present after RemoveComponent")
+	}
+}
+
+func TestEntityGenerationInvalidatesStaleHandles(t *testing.T) {
+	w := NewWorld()
+	e := w.NewEntity()
+	w.RemoveEntity(e)
+
+	if w.Alive(e) {
+		t.Fatal("Alive(e) = true after RemoveEntity")
+	}
+
+	e2 := w.NewEntity()
+	if e2.Index() != e.Index() {
+		t.Fatalf("expected slot reuse, got fresh index %d vs recycled %d", e2.Index(), e.Index())
+	}
+	if e2.Generation() == e.Generation() {
+		t.Fatal("recycled entity did not get a new generation")
+	}
+	if w.Alive(e) {
+		t.Fatal("stale Entity considered alive after its slot was recycled")
+	}
+}
+
+func TestStaleHandleCannotAliasRecycledSlotComponent(t *testing.T) {
+	w := NewWorld()
+	e1 := w.NewEntity()
+	AddComponent(w, e1, position{X: 1})
+	w.RemoveEntity(e1)
+
+	e2 := w.NewEntity()
+	AddComponent(w, e2, position{X: 2})
+
+	if _, ok := GetComponent[position](w, e1); ok {
+		t.Fatal("GetComponent(e1) = ok after e1's slot was recycled as e2")
+	}
+	if HasComponent[position](w, e1) {
+		t.Fatal("HasComponent(e1) = true after e1's slot was recycled as e2")
+	}
+	pos, ok := GetComponent[position](w, e2)
+	if !ok || pos.X != 2 {
+		t.Fatalf("GetComponent(e2) = %v, %v; want {2 0 0}, true", pos, ok)
+	}
+}
+
+func TestStaleHandleCannotStompRecycledSlotViaAddComponent(t *testing.T) {
+	w := NewWorld()
+	e1 := w.NewEntity()
+	AddComponent(w, e1, position{X: 1})
+	w.RemoveEntity(e1)
+
+	e2 := w.NewEntity()
+	AddComponent(w, e2, position{X: 2})
+
+	if AddComponent(w, e1, position{X: 999}) {
+		t.Fatal("AddComponent(e1) = true after e1's slot was recycled as e2")
+	}
+	pos, ok := GetComponent[position](w, e2)
+	if !ok || pos.X != 2 {
+		t.Fatalf("GetComponent(e2) = %v, %v; want {2 0 0}, stale AddComponent(e1) must not alter it", pos, ok)
+	}
+}
+
+func TestStoreSwapRemoveKeepsDenseContiguous(t *testing.T) {
+	w := NewWorld()
+	entities := make([]Entity, 5)
+	for i := range entities {
+		entities[i] = w.NewEntity()
+		AddComponent(w, entities[i], position{X: float32(i)})
+	}
+
+	RemoveComponent[position](w, entities[1])
+
+	store := Components[position](w)
+	if store.Len() != 4 {
+		t.Fatalf("Len() = %d; want 4", store.Len())
+	}
+
+	seen := make(map[float32]bool)
+	store.Each(func(e Entity, p *position) {
+		seen[p.X] = true
+	})
+	for _, x := range []float32{0, 2, 3, 4} {
+		if !seen[x] {
+			t.Fatalf("missing component with X=%v after swap-remove", x)
+		}
+	}
+	if seen[1] {
+		t.Fatal("removed component with X=1 still present")
+	}
+}
+
+func TestView2ForEachVisitsIntersection(t *testing.T) {
+	w := NewWorld()
+	both := w.NewEntity()
+	onlyPos := w.NewEntity()
+
+	AddComponent(w, both, position{X: 1})
+	AddComponent(w, both, velocity{X: 2})
+	AddComponent(w, onlyPos, position{X: 3})
+
+	var got []Entity
+	NewView2[position, velocity](w).ForEach(func(e Entity, p *position, v *velocity) {
+		got = append(got, e)
+	})
+
+	if len(got) != 1 || got[0] != both {
+		t.Fatalf("ForEach visited %v; want [%v]", got, both)
+	}
+}
+
+func BenchmarkView2ForEach8000(b *testing.B) {
+	w := NewWorld()
+	for i := 0; i < 8000; i++ {
+		e := w.NewEntity()
+		AddComponent(w, e, position{X: float32(i)})
+		AddComponent(w, e, velocity{X: 1})
+	}
+	view := NewView2[position, velocity](w)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		view.ForEach(func(e Entity, p *position, v *velocity) {
+			p.X += v.X
+		})
+	}
+}
+
+// BenchmarkMapLookup8000 is the bookkeeping style ECS replaced: a
+// map[*int]*velocity keyed by mesh pointer, as UniverseSpheres used to
+// keep fields keyed by *graphic.Mesh. It's here purely as a baseline for
+// BenchmarkView2ForEach8000.
+func BenchmarkMapLookup8000(b *testing.B) {
+	type mesh struct{ X, Y, Z float32 }
+	meshes := make([]*mesh, 8000)
+	fields := make(map[*mesh]*velocity, 8000)
+	for i := range meshes {
+		meshes[i] = &mesh{X: float32(i)}
+		fields[meshes[i]] = &velocity{X: 1}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, m := range meshes {
+			m.X += fields[m].X
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Package ecs is a small entity-component-system used to replace the
+// ad-hoc mesh slices and "mesh -> force field" maps that the universe
+// demos used to keep in sync by hand. Entities are opaque handles, state
+// lives in dense, cache-friendly Store[T] component tables, and behavior
+// lives in Systems that operate on Views of those stores.
+package ecs
+
+import "math"
+
+// Entity is an opaque handle into a World. The low 32 bits are the slot
+// index, the high 32 bits are that slot's generation; comparing a stale
+// Entity (one whose slot has since been recycled) against a live one is
+// always false, so holding on to a removed Entity is safe rather than a
+// use-after-free.
+type Entity uint64
+
+// NullEntity never refers to a live entity.
+const NullEntity Entity = 0
+
+func newEntity(index, generation uint32) Entity {
+	return Entity(generation)<<32 | Entity(index)
+}
+
+// Index returns the entity's slot index.
+func (e Entity) Index() uint32 {
+	return uint32(e & math.MaxUint32)
+}
+
+// Generation returns the entity's generation counter.
+func (e Entity) Generation() uint32 {
+	return uint32(e >> 32)
+}
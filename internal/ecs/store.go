@@ -0,0 +1,113 @@
+package ecs
+
+// Store[T] holds every live T component in a single dense, contiguous
+// slice so that iterating all of them (the common case for a tick system)
+// is a straight cache-friendly scan, while still supporting O(1)
+// add/remove/lookup by Entity via a sparse index keyed by entity slot.
+type Store[T any] struct {
+	world *World
+
+	dense    []T      // component data, tightly packed
+	entities []Entity // entities[i] owns dense[i]
+	sparse   []int32  // sparse[entity.Index()] -> index into dense, or -1
+}
+
+func newStore[T any](w *World) *Store[T] {
+	return &Store[T]{world: w}
+}
+
+func (s *Store[T]) ensureSparse(index uint32) {
+	for uint32(len(s.sparse)) <= index {
+		s.sparse = append(s.sparse, -1)
+	}
+}
+
+// add attaches value to e, overwriting any existing component. It
+// reports whether e was live; a stale e (one whose slot has since been
+// recycled) is rejected rather than silently aliasing whatever entity
+// now owns that slot.
+func (s *Store[T]) add(e Entity, value T) bool {
+	if !s.world.Alive(e) {
+		return false
+	}
+	index := e.Index()
+	s.ensureSparse(index)
+
+	if slot := s.sparse[index]; slot != -1 {
+		s.dense[slot] = value
+		return true
+	}
+
+	s.sparse[index] = int32(len(s.dense))
+	s.dense = append(s.dense, value)
+	s.entities = append(s.entities, e)
+	return true
+}
+
+// get returns a pointer to e's component for in-place mutation. A stale
+// e (one whose slot has since been recycled under a new generation)
+// never matches, even if the recycled entity happens to carry a
+// component in this store.
+func (s *Store[T]) get(e Entity) (*T, bool) {
+	if !s.world.Alive(e) {
+		return nil, false
+	}
+	index := e.Index()
+	if index >= uint32(len(s.sparse)) {
+		return nil, false
+	}
+	slot := s.sparse[index]
+	if slot == -1 {
+		return nil, false
+	}
+	return &s.dense[slot], true
+}
+
+// has reports whether e has a component in this store.
+func (s *Store[T]) has(e Entity) bool {
+	if !s.world.Alive(e) {
+		return false
+	}
+	index := e.Index()
+	return index < uint32(len(s.sparse)) && s.sparse[index] != -1
+}
+
+// remove detaches e's component, swap-removing it from the dense slice so
+// the store never needs to shift every following element down by one.
+func (s *Store[T]) remove(e Entity) bool {
+	if !s.world.Alive(e) {
+		return false
+	}
+	index := e.Index()
+	if index >= uint32(len(s.sparse)) {
+		return false
+	}
+	slot := s.sparse[index]
+	if slot == -1 {
+		return false
+	}
+
+	last := int32(len(s.dense)) - 1
+	if slot != last {
+		s.dense[slot] = s.dense[last]
+		s.entities[slot] = s.entities[last]
+		s.sparse[s.entities[slot].Index()] = slot
+	}
+	s.dense = s.dense[:last]
+	s.entities = s.entities[:last]
+	s.sparse[index] = -1
+	return true
+}
+
+// Len returns the number of live components in the store.
+func (s *Store[T]) Len() int {
+	return len(s.dense)
+}
+
+// Each calls fn for every (Entity, *T) pair, in dense storage order. fn
+// must not add or remove components of this type while iterating.
+func (s *Store[T]) Each(fn func(Entity, *T)) {
+	for i := range s.dense {
+		fn(s.entities[i], &s.dense[i])
+	}
+}
@@ -0,0 +1,31 @@
+package ecs
+
+import "time"
+
+// TickSystem is stepped from a demo's Update with the frame's delta time,
+// e.g. RandomWalkSystem or AttractorFollowSystem.
+type TickSystem interface {
+	Tick(w *World, dt time.Duration)
+}
+
+// RenderSystem pushes component state out to whatever actually draws the
+// frame (currently g3n meshes); it never mutates simulation state.
+type RenderSystem interface {
+	Render(w *World)
+}
+
+// TickSystemFunc adapts a plain function to a TickSystem.
+type TickSystemFunc func(w *World, dt time.Duration)
+
+// Tick implements TickSystem.
+func (f TickSystemFunc) Tick(w *World, dt time.Duration) {
+	f(w, dt)
+}
+
+// RenderSystemFunc adapts a plain function to a RenderSystem.
+type RenderSystemFunc func(w *World)
+
+// Render implements RenderSystem.
+func (f RenderSystemFunc) Render(w *World) {
+	f(w)
+}
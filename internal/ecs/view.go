@@ -0,0 +1,96 @@
+package ecs
+
+// View2 iterates every entity that has both an A and a B component. Go
+// generics don't support variadic type parameters, so wider views
+// (View3, ...) follow the same pattern, added as new call sites need
+// them.
+//
+// It always scans whichever of the two stores is smaller and probes the
+// other with Has, which keeps the common case (one populous store, one
+// sparse "tag" store) close to O(min(len(A), len(B))) rather than
+// O(len(A)+len(B)).
+type View2[A, B any] struct {
+	a *Store[A]
+	b *Store[B]
+}
+
+// NewView2 builds a View2 over the current component stores for A and B.
+func NewView2[A, B any](w *World) View2[A, B] {
+	return View2[A, B]{a: Components[A](w), b: Components[B](w)}
+}
+
+// ForEach calls fn for every entity with both components. fn must not add
+// or remove A or B components while iterating.
+func (v View2[A, B]) ForEach(fn func(e Entity, a *A, b *B)) {
+	if v.a.Len() <= v.b.Len() {
+		v.a.Each(func(e Entity, av *A) {
+			if bv, ok := v.b.get(e); ok {
+				fn(e, av, bv)
+			}
+		})
+		return
+	}
+	v.b.Each(func(e Entity, bv *B) {
+		if av, ok := v.a.get(e); ok {
+			fn(e, av, bv)
+		}
+	})
+}
+
+// View3 iterates every entity that has an A, a B and a C component.
+type View3[A, B, C any] struct {
+	a *Store[A]
+	b *Store[B]
+	c *Store[C]
+}
+
+// NewView3 builds a View3 over the current component stores for A, B and C.
+func NewView3[A, B, C any](w *World) View3[A, B, C] {
+	return View3[A, B, C]{a: Components[A](w), b: Components[B](w), c: Components[C](w)}
+}
+
+// ForEach calls fn for every entity with all three components. fn must
+// not add or remove A, B or C components while iterating.
+func (v View3[A, B, C]) ForEach(fn func(e Entity, a *A, b *B, c *C)) {
+	smallest := v.a.Len()
+	which := 0
+	if v.b.Len() < smallest {
+		smallest, which = v.b.Len(), 1
+	}
+	if v.c.Len() < smallest {
+		which = 2
+	}
+
+	switch which {
+	case 0:
+		v.a.Each(func(e Entity, av *A) {
+			bv, ok := v.b.get(e)
+			if !ok {
+				return
+			}
+			if cv, ok := v.c.get(e); ok {
+				fn(e, av, bv, cv)
+			}
+		})
+	case 1:
+		v.b.Each(func(e Entity, bv *B) {
+			av, ok := v.a.get(e)
+			if !ok {
+				return
+			}
+			if cv, ok := v.c.get(e); ok {
+				fn(e, av, bv, cv)
+			}
+		})
+	case 2:
+		v.c.Each(func(e Entity, cv *C) {
+			av, ok := v.a.get(e)
+			if !ok {
+				return
+			}
+			if bv, ok := v.b.get(e); ok {
+				fn(e, av, bv, cv)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package ecs
+
+import "reflect"
+
+// componentStore is the type-erased side of Store[T] that World needs in
+// order to clean up every component of an entity on RemoveEntity without
+// knowing T.
+type componentStore interface {
+	remove(e Entity) bool
+}
+
+// World owns entity allocation and the set of registered component
+// stores. The zero value is not usable; create one with NewWorld.
+type World struct {
+	generations []uint32 // generation currently live at each index
+	freeList    []uint32 // recycled indices, generation already bumped
+
+	stores map[reflect.Type]componentStore
+}
+
+// NewWorld creates an empty World.
+func NewWorld() *World {
+	return &World{stores: make(map[reflect.Type]componentStore)}
+}
+
+// NewEntity allocates a new Entity, reusing a recycled slot when one is
+// available so live entity indices stay dense.
+func (w *World) NewEntity() Entity {
+	if n := len(w.freeList); n > 0 {
+		index := w.freeList[n-1]
+		w.freeList = w.freeList[:n-1]
+		return newEntity(index, w.generations[index])
+	}
+
+	index := uint32(len(w.generations))
+	w.generations = append(w.generations, 1)
+	return newEntity(index, 1)
+}
+
+// Alive reports whether e still refers to a live entity, i.e. its slot
+// has not been recycled since e was created.
+func (w *World) Alive(e Entity) bool {
+	index := e.Index()
+	return int(index) < len(w.generations) && w.generations[index] == e.Generation()
+}
+
+// RemoveEntity removes every component attached to e across all stores
+// and recycles its slot under a bumped generation, invalidating any copy
+// of e still held elsewhere.
+func (w *World) RemoveEntity(e Entity) {
+	if !w.Alive(e) {
+		return
+	}
+	for _, store := range w.stores {
+		store.remove(e)
+	}
+
+	index := e.Index()
+	w.generations[index]++
+	w.freeList = append(w.freeList, index)
+}
+
+func storeOf[T any](w *World) *Store[T] {
+	key := reflect.TypeOf((*T)(nil))
+	if existing, ok := w.stores[key]; ok {
+		return existing.(*Store[T])
+	}
+	s := newStore[T](w)
+	w.stores[key] = s
+	return s
+}
+
+// AddComponent attaches a T component to e, replacing any existing T
+// already attached. It reports whether e was live; a stale e is rejected
+// and leaves whatever entity currently occupies its recycled slot
+// untouched.
+func AddComponent[T any](w *World, e Entity, value T) bool {
+	return storeOf[T](w).add(e, value)
+}
+
+// RemoveComponent detaches e's T component, if any. It reports whether a
+// component was present.
+func RemoveComponent[T any](w *World, e Entity) bool {
+	return storeOf[T](w).remove(e)
+}
+
+// GetComponent returns a pointer to e's T component for in-place
+// mutation, and whether e has one.
+func GetComponent[T any](w *World, e Entity) (*T, bool) {
+	return storeOf[T](w).get(e)
+}
+
+// HasComponent reports whether e has a T component.
+func HasComponent[T any](w *World, e Entity) bool {
+	return storeOf[T](w).has(e)
+}
+
+// Components returns the Store[T] backing T, for callers that want to
+// build a View or iterate directly rather than going through
+// Add/Remove/GetComponent one entity at a time.
+func Components[T any](w *World) *Store[T] {
+	return storeOf[T](w)
+}
@@ -0,0 +1,39 @@
+package net
+
+import "github.com/gorilla/websocket"
+
+// Client is a WebSocket connection to a Server's ServeWS endpoint. It lets a
+// demo observe and drive the authoritative simulation the same way any
+// other networked player would, rather than reaching into the Server
+// in-process.
+type Client struct {
+	conn *websocket.Conn
+}
+
+// Dial connects to a Server's ServeWS endpoint at url (e.g.
+// "ws://127.0.0.1:8080/ws?id=local").
+func Dial(url string) (*Client, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Send writes a ClientMessage to the server.
+func (c *Client) Send(msg ClientMessage) error {
+	return c.conn.WriteJSON(msg)
+}
+
+// Recv blocks until the next WorldState broadcast arrives, or returns an
+// error once the connection is closed.
+func (c *Client) Recv() (WorldState, error) {
+	var state WorldState
+	err := c.conn.ReadJSON(&state)
+	return state, err
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,59 @@
+package net
+
+import "github.com/g3n/engine/math32"
+
+// ClientMessageType identifies the kind of command a client is sending to
+// the server. The set mirrors the key bindings of the interactive
+// universe.gravity_movement_editor demo so the same verbs work whether a
+// player is local or networked.
+type ClientMessageType string
+
+const (
+	MsgSpawnSphere      ClientMessageType = "spawn_sphere"
+	MsgMoveReference    ClientMessageType = "move_reference"
+	MsgPause            ClientMessageType = "pause"
+	MsgResume           ClientMessageType = "resume"
+	MsgStep             ClientMessageType = "step"
+	MsgSetAttractorMass ClientMessageType = "set_attractor_mass"
+)
+
+// ClientMessage is a single command sent from a connected client to the
+// server. Sequence is a monotonically increasing per-client counter used by
+// the client-side prediction/reconciliation layer to identify which inputs
+// a given snapshot has already applied.
+type ClientMessage struct {
+	Type     ClientMessageType `json:"type"`
+	Sequence uint32            `json:"sequence"`
+
+	// Move is the reference-mesh translation requested by MsgMoveReference,
+	// expressed in the same units as graphic.Mesh.Translate*.
+	Move math32.Vector3 `json:"move,omitempty"`
+
+	// Mass is the attractor mass requested by MsgSetAttractorMass.
+	Mass float32 `json:"mass,omitempty"`
+}
+
+// BodySnapshot is the networked state of a single simulated sphere.
+type BodySnapshot struct {
+	Name        string            `json:"name"`
+	Position    math32.Vector3    `json:"position"`
+	Velocity    math32.Vector3    `json:"velocity"`
+	Orientation math32.Quaternion `json:"orientation"`
+}
+
+// WorldState is the authoritative snapshot broadcast to every connected
+// client once per send tick.
+type WorldState struct {
+	// Tick is the physics step this snapshot was produced at.
+	Tick uint64 `json:"tick"`
+
+	// LastSequence maps a client ID to the last sequence number from that
+	// client the server had processed when this snapshot was produced. A
+	// client uses its own entry to discard acknowledged inputs during
+	// reconciliation.
+	LastSequence map[string]uint32 `json:"lastSequence"`
+
+	Reference math32.Vector3 `json:"reference"`
+	Bodies    []BodySnapshot `json:"bodies"`
+	Paused    bool           `json:"paused"`
+}
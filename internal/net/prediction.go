@@ -0,0 +1,198 @@
+package net
+
+import (
+	"time"
+
+	"github.com/g3n/engine/math32"
+)
+
+// PredictionConfig tunes the client-side prediction and entity
+// interpolation described by PredictedBody and RemoteBuffer.
+type PredictionConfig struct {
+	// InterpolationDelay is how far in the past remote entities are
+	// rendered, buying time for a straddling pair of snapshots to arrive.
+	InterpolationDelay time.Duration
+
+	// MaxExtrapolation bounds how far past the newest buffered snapshot a
+	// remote entity may be extrapolated using its last-known velocity
+	// before RemoteBuffer simply holds it at the last known position.
+	MaxExtrapolation time.Duration
+
+	// BufferSize is how many snapshots RemoteBuffer retains.
+	BufferSize int
+}
+
+// DefaultPredictionConfig matches the ~100ms interpolation window called
+// for by the design: enough buffering to smooth over jitter at the
+// server's SendRate without feeling laggy.
+func DefaultPredictionConfig() PredictionConfig {
+	return PredictionConfig{
+		InterpolationDelay: 100 * time.Millisecond,
+		MaxExtrapolation:   200 * time.Millisecond,
+		BufferSize:         32,
+	}
+}
+
+// PendingInput is one locally-applied input, kept until the server
+// acknowledges having processed it.
+type PendingInput struct {
+	Sequence uint32
+	Move     math32.Vector3
+	DT       time.Duration
+}
+
+// PredictedBody is the client-side state for a locally-owned body (the
+// reference mesh, or any body the local player controls). It integrates
+// inputs immediately for responsiveness, then reconciles against
+// authoritative snapshots as they arrive.
+type PredictedBody struct {
+	cfg PredictionConfig
+
+	nextSequence uint32
+	pending      []PendingInput
+
+	Position math32.Vector3
+}
+
+// NewPredictedBody creates a PredictedBody starting at pos.
+func NewPredictedBody(cfg PredictionConfig, pos math32.Vector3) *PredictedBody {
+	return &PredictedBody{cfg: cfg, Position: pos}
+}
+
+// ApplyInput integrates a new input into the predicted position
+// immediately, tags it with the next sequence number, and keeps it in the
+// pending ring buffer until it is acknowledged. It returns the sequence
+// number assigned so the caller can attach it to the ClientMessage it
+// sends to the server.
+func (p *PredictedBody) ApplyInput(move math32.Vector3, dt time.Duration) uint32 {
+	seq := p.nextSequence
+	p.nextSequence++
+
+	p.integrate(move, dt)
+	p.pending = append(p.pending, PendingInput{Sequence: seq, Move: move, DT: dt})
+
+	if len(p.pending) > p.cfg.BufferSize*4 {
+		// The server has stopped acknowledging inputs (e.g. disconnect);
+		// drop the oldest half rather than growing without bound.
+		p.pending = append([]PendingInput{}, p.pending[len(p.pending)/2:]...)
+	}
+
+	return seq
+}
+
+// Reconcile snaps the predicted position to an authoritative position for
+// lastProcessed, discards every acknowledged pending input, and replays
+// whatever inputs are left to arrive back at a corrected predicted state.
+func (p *PredictedBody) Reconcile(authoritative math32.Vector3, lastProcessed uint32) {
+	p.Position = authoritative
+
+	remaining := p.pending[:0]
+	for _, input := range p.pending {
+		if input.Sequence <= lastProcessed {
+			continue
+		}
+		p.integrate(input.Move, input.DT)
+		remaining = append(remaining, input)
+	}
+	p.pending = remaining
+}
+
+func (p *PredictedBody) integrate(move math32.Vector3, dt time.Duration) {
+	scale := float32(dt.Seconds())
+	p.Position.X += move.X * scale
+	p.Position.Y += move.Y * scale
+	p.Position.Z += move.Z * scale
+}
+
+// remoteSample is one buffered observation of a remote entity, stamped
+// with the local time it was received.
+type remoteSample struct {
+	receivedAt  time.Time
+	position    math32.Vector3
+	velocity    math32.Vector3
+	orientation math32.Quaternion
+}
+
+// RemoteBuffer buffers recent snapshots of a single remote entity and
+// renders it InterpolationDelay in the past by lerping between the two
+// samples that straddle the render time, falling back to velocity-based
+// extrapolation when no newer sample has arrived yet.
+type RemoteBuffer struct {
+	cfg     PredictionConfig
+	samples []remoteSample
+}
+
+// NewRemoteBuffer creates an empty RemoteBuffer.
+func NewRemoteBuffer(cfg PredictionConfig) *RemoteBuffer {
+	return &RemoteBuffer{cfg: cfg}
+}
+
+// Push records a newly received snapshot for this entity, received at
+// the given local time.
+func (b *RemoteBuffer) Push(receivedAt time.Time, position, velocity math32.Vector3, orientation math32.Quaternion) {
+	b.samples = append(b.samples, remoteSample{
+		receivedAt:  receivedAt,
+		position:    position,
+		velocity:    velocity,
+		orientation: orientation,
+	})
+
+	if len(b.samples) > b.cfg.BufferSize {
+		b.samples = b.samples[len(b.samples)-b.cfg.BufferSize:]
+	}
+}
+
+// RemoteState is a remote entity's rendered pose: position and
+// orientation resolved for a particular point in time.
+type RemoteState struct {
+	Position    math32.Vector3
+	Orientation math32.Quaternion
+}
+
+// StateAt returns the entity's interpolated (or, if necessary,
+// extrapolated) pose to render at local time now.
+func (b *RemoteBuffer) StateAt(now time.Time) RemoteState {
+	renderTime := now.Add(-b.cfg.InterpolationDelay)
+
+	if len(b.samples) == 0 {
+		return RemoteState{}
+	}
+	if len(b.samples) == 1 {
+		s := b.samples[0]
+		return RemoteState{Position: s.position, Orientation: s.orientation}
+	}
+
+	// Find the pair of samples straddling renderTime.
+	for i := 0; i < len(b.samples)-1; i++ {
+		a, c := b.samples[i], b.samples[i+1]
+		if renderTime.Before(a.receivedAt) || renderTime.After(c.receivedAt) {
+			continue
+		}
+		span := c.receivedAt.Sub(a.receivedAt)
+		if span <= 0 {
+			return RemoteState{Position: c.position, Orientation: c.orientation}
+		}
+		alpha := float32(renderTime.Sub(a.receivedAt)) / float32(span)
+		pos := a.position
+		orient := a.orientation
+		return RemoteState{
+			Position:    *pos.Lerp(&c.position, alpha),
+			Orientation: *orient.Slerp(&c.orientation, alpha),
+		}
+	}
+
+	newest := b.samples[len(b.samples)-1]
+	lag := renderTime.Sub(newest.receivedAt)
+	if lag <= 0 {
+		return RemoteState{Position: newest.position, Orientation: newest.orientation}
+	}
+	if lag > b.cfg.MaxExtrapolation {
+		lag = b.cfg.MaxExtrapolation
+	}
+
+	extrapolated := newest.position
+	extrapolated.X += newest.velocity.X * float32(lag.Seconds())
+	extrapolated.Y += newest.velocity.Y * float32(lag.Seconds())
+	extrapolated.Z += newest.velocity.Z * float32(lag.Seconds())
+	return RemoteState{Position: extrapolated, Orientation: newest.orientation}
+}
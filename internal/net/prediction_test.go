@@ -0,0 +1,73 @@
+package net
+
+import (
+	"testing"
+	"time"
+
+	"github.com/g3n/engine/math32"
+)
+
+func TestApplyInputIntegratesAndAssignsSequence(t *testing.T) {
+	p := NewPredictedBody(DefaultPredictionConfig(), math32.Vector3{})
+
+	seq0 := p.ApplyInput(math32.Vector3{X: 1}, time.Second)
+	seq1 := p.ApplyInput(math32.Vector3{X: 1}, time.Second)
+
+	if seq0 != 0 || seq1 != 1 {
+		t.Fatalf("sequences = %d, %d; want 0, 1", seq0, seq1)
+	}
+	if want := (math32.Vector3{X: 2}); p.Position != want {
+		t.Fatalf("Position = %v; want %v", p.Position, want)
+	}
+}
+
+func TestReconcileDiscardsAcknowledgedAndReplaysPending(t *testing.T) {
+	p := NewPredictedBody(DefaultPredictionConfig(), math32.Vector3{})
+
+	p.ApplyInput(math32.Vector3{X: 1}, time.Second) // seq 0, acknowledged below
+	p.ApplyInput(math32.Vector3{X: 1}, time.Second) // seq 1, still pending
+
+	// The server processed seq 0 and landed the reference elsewhere (e.g. a
+	// second client also moved it); Reconcile must snap to that authority
+	// and then replay only the unacknowledged seq 1 input on top of it.
+	p.Reconcile(math32.Vector3{X: 10}, 0)
+
+	if want := (math32.Vector3{X: 11}); p.Position != want {
+		t.Fatalf("Position after Reconcile = %v; want %v", p.Position, want)
+	}
+	if len(p.pending) != 1 || p.pending[0].Sequence != 1 {
+		t.Fatalf("pending = %+v; want exactly seq 1 retained", p.pending)
+	}
+}
+
+func TestApplyInputDropsOldestPendingOnUnboundedGrowth(t *testing.T) {
+	cfg := DefaultPredictionConfig()
+	cfg.BufferSize = 2
+	p := NewPredictedBody(cfg, math32.Vector3{})
+
+	var lastSeq uint32
+	for i := 0; i < cfg.BufferSize*4+1; i++ {
+		lastSeq = p.ApplyInput(math32.Vector3{X: 1}, time.Second)
+	}
+
+	if len(p.pending) >= int(lastSeq)+1 {
+		t.Fatalf("pending len = %d; want it trimmed below the full input count %d", len(p.pending), lastSeq+1)
+	}
+}
+
+func TestRemoteBufferStateAtInterpolatesBetweenStraddlingSamples(t *testing.T) {
+	cfg := DefaultPredictionConfig()
+	cfg.InterpolationDelay = 100 * time.Millisecond
+	b := NewRemoteBuffer(cfg)
+
+	t0 := time.Now()
+	b.Push(t0, math32.Vector3{X: 0}, math32.Vector3{}, math32.Quaternion{})
+	b.Push(t0.Add(200*time.Millisecond), math32.Vector3{X: 10}, math32.Vector3{}, math32.Quaternion{})
+
+	// Rendering 100ms behind "now" (t0+200ms) lands exactly on t0+100ms,
+	// halfway between the two samples.
+	state := b.StateAt(t0.Add(200 * time.Millisecond))
+	if math32.Abs(state.Position.X-5) > 1e-3 {
+		t.Fatalf("Position.X = %v; want ~5 (halfway between the straddling samples)", state.Position.X)
+	}
+}
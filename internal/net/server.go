@@ -0,0 +1,253 @@
+// Package net hosts an authoritative physics simulation of the
+// universe.gravity_movement_editor demo and streams its world state to
+// connected clients over WebSocket (see ServeWS and Client), decoupling
+// the simulation tick rate from how often snapshots are sent out. It
+// mirrors the key bindings of the interactive demo as typed
+// ClientMessages so the same world can be driven by any connected client.
+package net
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/g3n/engine/core"
+	"github.com/g3n/engine/experimental/physics"
+	"github.com/g3n/engine/experimental/physics/object"
+	"github.com/g3n/engine/geometry"
+	"github.com/g3n/engine/graphic"
+	"github.com/g3n/engine/material"
+	"github.com/g3n/engine/math32"
+	"github.com/g3n/engine/util/logger"
+)
+
+const (
+	// PhysicsTickRate is how many times per second the authoritative
+	// simulation steps, independent of how often it is observed.
+	PhysicsTickRate = 60
+
+	// SendRate is how many world-state snapshots per second are broadcast
+	// to connected clients. Keeping it below PhysicsTickRate lets the
+	// network payload stay small while the simulation itself stays stable.
+	SendRate = 20
+)
+
+// Server hosts one authoritative instance of the spheres-and-attractors
+// world and drives it forward at a fixed physics timestep regardless of
+// how many clients are connected or how fast they can be sent snapshots.
+type Server struct {
+	mu  sync.RWMutex
+	log *logger.Logger
+
+	scene         *core.Node
+	sim           *physics.Simulation
+	referenceMesh *graphic.Mesh
+	bodies        map[string]*object.Body
+	fields        map[string]*physics.AttractorForceField
+
+	sessions map[string]*Session
+
+	tick uint64
+	next int
+}
+
+// NewServer creates a Server with an empty world and a reference mesh at
+// the origin, matching universe.gravity_movement_editor's starting state.
+func NewServer() *Server {
+	s := &Server{
+		log:      logger.New("NET", nil),
+		scene:    core.NewNode(),
+		bodies:   make(map[string]*object.Body),
+		fields:   make(map[string]*physics.AttractorForceField),
+		sessions: make(map[string]*Session),
+	}
+	s.sim = physics.NewSimulation(s.scene)
+
+	refGeom := geometry.NewSphere(1.5, 16, 16)
+	refMat := material.NewStandard(&math32.Color{R: 0.9, G: 0.6, B: 0.3})
+	s.referenceMesh = graphic.NewMesh(refGeom, refMat)
+	s.scene.Add(s.referenceMesh)
+
+	return s
+}
+
+// Register creates and returns a new Session for a connecting client,
+// under the server's write lock so it is immediately visible to the next
+// broadcast.
+func (s *Server) Register(id string, send func(WorldState)) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session := &Session{ID: id, Send: send}
+	s.sessions[id] = session
+	s.log.Info("client %s connected", id)
+	return session
+}
+
+// Unregister removes a disconnected client's session, but only if session
+// is still the one registered under its ID. This guards against a stale
+// connection's teardown running after a reconnect under the same ID has
+// already installed a newer Session: without the identity check, the old
+// connection's deferred Unregister would delete the live replacement from
+// the registry while its socket stayed open, making it invisible to
+// Snapshot and broadcast forever.
+func (s *Server) Unregister(session *Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sessions[session.ID] != session {
+		return
+	}
+	delete(s.sessions, session.ID)
+	s.log.Info("client %s disconnected", session.ID)
+}
+
+// HandleMessage applies a client command to the authoritative world and
+// records it as that session's last-processed sequence number. session
+// must be the same *Session returned by Register; if a reconnect under
+// the same ID has since replaced it in the registry, the message is
+// dropped rather than applied against the newer session, mirroring the
+// identity check in Unregister.
+func (s *Server) HandleMessage(session *Session, msg ClientMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sessions[session.ID] != session {
+		return
+	}
+	if msg.Sequence > session.lastSequence {
+		session.lastSequence = msg.Sequence
+	}
+
+	switch msg.Type {
+	case MsgSpawnSphere:
+		s.spawnSphereLocked()
+	case MsgMoveReference:
+		s.referenceMesh.TranslateX(msg.Move.X)
+		s.referenceMesh.TranslateY(msg.Move.Y)
+		s.referenceMesh.TranslateZ(msg.Move.Z)
+	case MsgPause:
+		s.sim.SetPaused(true)
+	case MsgResume:
+		s.sim.SetPaused(false)
+	case MsgStep:
+		s.sim.Step(1.0 / PhysicsTickRate)
+	case MsgSetAttractorMass:
+		pos := s.referenceMesh.Position()
+		for _, field := range s.fields {
+			field.SetMass(msg.Mass)
+			field.SetPosition(&pos)
+		}
+	}
+}
+
+// spawnSphereLocked adds a new sphere body at the reference mesh's current
+// position, mirroring UniverseSpheresEditor.addNewSphere. Callers must
+// hold s.mu.
+func (s *Server) spawnSphereLocked() {
+	geom := geometry.NewSphere(0.1, 20, 20)
+	mat := material.NewStandard(math32.NewColor("Black"))
+	mesh := graphic.NewMesh(geom, mat)
+
+	pos := s.referenceMesh.Position()
+	mesh.SetPositionVec(&pos)
+	s.scene.Add(mesh)
+
+	name := namedSphere(s.next)
+	s.next++
+
+	body := object.NewBody(mesh)
+	s.sim.AddBody(body, name)
+	s.bodies[name] = body
+
+	field := physics.NewAttractorForceField(&pos, 1)
+	s.fields[name] = field
+	s.sim.AddForceField(field)
+}
+
+func namedSphere(i int) string {
+	return "sphere-" + strconv.Itoa(i)
+}
+
+// Run blocks, driving the simulation at PhysicsTickRate and broadcasting a
+// WorldState to every session at SendRate, until stop is closed.
+func (s *Server) Run(stop <-chan struct{}) {
+	physicsTicker := time.NewTicker(time.Second / PhysicsTickRate)
+	defer physicsTicker.Stop()
+	sendTicker := time.NewTicker(time.Second / SendRate)
+	defer sendTicker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-physicsTicker.C:
+			s.step()
+		case <-sendTicker.C:
+			s.broadcast()
+		}
+	}
+}
+
+func (s *Server) step() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sim.Paused() {
+		return
+	}
+	for name, body := range s.bodies {
+		pos := body.Position()
+		s.fields[name].SetPosition(&pos)
+	}
+	s.sim.Step(1.0 / PhysicsTickRate)
+	s.tick++
+}
+
+// Snapshot returns the current authoritative WorldState.
+func (s *Server) Snapshot() WorldState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snapshotLocked()
+}
+
+func (s *Server) snapshotLocked() WorldState {
+	bodies := make([]BodySnapshot, 0, len(s.bodies))
+	for name, body := range s.bodies {
+		bodies = append(bodies, BodySnapshot{
+			Name:        name,
+			Position:    body.Position(),
+			Velocity:    body.Velocity(),
+			Orientation: *body.Quaternion(),
+		})
+	}
+
+	lastSeq := make(map[string]uint32, len(s.sessions))
+	for id, session := range s.sessions {
+		lastSeq[id] = session.lastSequence
+	}
+
+	return WorldState{
+		Tick:         s.tick,
+		LastSequence: lastSeq,
+		Reference:    s.referenceMesh.Position(),
+		Bodies:       bodies,
+		Paused:       s.sim.Paused(),
+	}
+}
+
+func (s *Server) broadcast() {
+	s.mu.RLock()
+	state := s.snapshotLocked()
+	sessions := make([]*Session, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	s.mu.RUnlock()
+
+	for _, session := range sessions {
+		if session.Send != nil {
+			session.Send(state)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package net
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestHandleMessageSpawnSphereAddsBody(t *testing.T) {
+	s := NewServer()
+	session := s.Register("alice", nil)
+
+	if got := len(s.Snapshot().Bodies); got != 0 {
+		t.Fatalf("initial body count = %d; want 0", got)
+	}
+
+	s.HandleMessage(session, ClientMessage{Type: MsgSpawnSphere, Sequence: 1})
+	s.HandleMessage(session, ClientMessage{Type: MsgSpawnSphere, Sequence: 2})
+
+	if got := len(s.Snapshot().Bodies); got != 2 {
+		t.Fatalf("body count after two spawns = %d; want 2", got)
+	}
+}
+
+func TestHandleMessagePauseResumeStepTogglesSimPaused(t *testing.T) {
+	s := NewServer()
+	session := s.Register("bob", nil)
+
+	if s.Snapshot().Paused {
+		t.Fatalf("Paused = true before any pause message")
+	}
+
+	s.HandleMessage(session, ClientMessage{Type: MsgPause, Sequence: 1})
+	if !s.Snapshot().Paused {
+		t.Fatalf("Paused = false after MsgPause")
+	}
+
+	s.HandleMessage(session, ClientMessage{Type: MsgResume, Sequence: 2})
+	if s.Snapshot().Paused {
+		t.Fatalf("Paused = true after MsgResume")
+	}
+
+	s.HandleMessage(session, ClientMessage{Type: MsgPause, Sequence: 3})
+	s.HandleMessage(session, ClientMessage{Type: MsgStep, Sequence: 4})
+	if !s.Snapshot().Paused {
+		t.Fatalf("Paused = false after MsgStep while paused; MsgStep must not resume the sim")
+	}
+}
+
+func TestHandleMessageLastSequenceIsMonotonic(t *testing.T) {
+	s := NewServer()
+	session := s.Register("carol", nil)
+
+	s.HandleMessage(session, ClientMessage{Type: MsgSpawnSphere, Sequence: 5})
+	if got := session.LastSequence(); got != 5 {
+		t.Fatalf("LastSequence = %d; want 5", got)
+	}
+
+	// A stale (older) sequence arriving out of order must not roll the
+	// bookkeeping backwards.
+	s.HandleMessage(session, ClientMessage{Type: MsgSpawnSphere, Sequence: 2})
+	if got := session.LastSequence(); got != 5 {
+		t.Fatalf("LastSequence = %d after stale sequence 2; want unchanged 5", got)
+	}
+
+	s.HandleMessage(session, ClientMessage{Type: MsgSpawnSphere, Sequence: 9})
+	if got := session.LastSequence(); got != 9 {
+		t.Fatalf("LastSequence = %d; want 9", got)
+	}
+
+	if got := s.Snapshot().LastSequence[session.ID]; got != 9 {
+		t.Fatalf("Snapshot LastSequence[%q] = %d; want 9", session.ID, got)
+	}
+}
+
+// TestRegisterUnregisterUnderConcurrentAccess exercises the session
+// registry from many goroutines at once; run with -race to catch data
+// races in the sync.RWMutex-guarded map.
+func TestRegisterUnregisterUnderConcurrentAccess(t *testing.T) {
+	s := NewServer()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		id := "client-" + strconv.Itoa(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			session := s.Register(id, nil)
+			s.HandleMessage(session, ClientMessage{Type: MsgSpawnSphere, Sequence: 1})
+			_ = s.Snapshot()
+			s.Unregister(session)
+		}()
+	}
+	wg.Wait()
+
+	if got := len(s.Snapshot().LastSequence); got != 0 {
+		t.Fatalf("sessions remaining after concurrent register/unregister = %d; want 0", got)
+	}
+}
+
+// TestUnregisterDoesNotClobberNewerSessionUnderSameID covers a reconnect
+// racing the old connection's teardown: the stale session's Unregister
+// must not delete the newer session that has since taken over its ID.
+func TestUnregisterDoesNotClobberNewerSessionUnderSameID(t *testing.T) {
+	s := NewServer()
+
+	stale := s.Register("dupe", nil)
+	fresh := s.Register("dupe", nil)
+
+	s.Unregister(stale)
+	if got := len(s.Snapshot().LastSequence); got != 1 {
+		t.Fatalf("sessions after stale Unregister = %d; want 1 (fresh session must survive)", got)
+	}
+
+	s.Unregister(fresh)
+	if got := len(s.Snapshot().LastSequence); got != 0 {
+		t.Fatalf("sessions after fresh Unregister = %d; want 0", got)
+	}
+}
+
+// TestHandleMessageDoesNotApplyAgainstNewerSessionUnderSameID covers a
+// reconnect racing a buffered read on the stale connection: a message
+// still in flight on the old socket, dispatched through the old
+// ServeWS goroutine after a reconnect has replaced it under the same
+// ID, must not be applied against the newer session or forge its
+// lastSequence.
+func TestHandleMessageDoesNotApplyAgainstNewerSessionUnderSameID(t *testing.T) {
+	s := NewServer()
+
+	stale := s.Register("dupe", nil)
+	fresh := s.Register("dupe", nil)
+
+	s.HandleMessage(fresh, ClientMessage{Type: MsgSpawnSphere, Sequence: 7})
+	if got := fresh.LastSequence(); got != 7 {
+		t.Fatalf("fresh.LastSequence = %d; want 7", got)
+	}
+
+	// A message still in flight on the stale connection's socket, applied
+	// through the stale *Session after the reconnect has taken over "dupe".
+	s.HandleMessage(stale, ClientMessage{Type: MsgSpawnSphere, Sequence: 99})
+
+	if got := fresh.LastSequence(); got != 7 {
+		t.Fatalf("fresh.LastSequence = %d after stale HandleMessage; want unchanged 7", got)
+	}
+	if got := len(s.Snapshot().Bodies); got != 1 {
+		t.Fatalf("body count = %d after stale HandleMessage; want 1 (stale spawn must not apply)", got)
+	}
+}
@@ -0,0 +1,24 @@
+package net
+
+// Session tracks one connected client's identity and last-acknowledged
+// input. The transport (currently a gorilla/websocket connection, see
+// ServeWS in transport.go) owns reading/writing; Session only owns the
+// state the simulation needs to know about that client.
+type Session struct {
+	ID string
+
+	// lastSequence is the highest ClientMessage.Sequence processed for this
+	// session so far. It is echoed back in WorldState.LastSequence so the
+	// client can reconcile its predicted state.
+	lastSequence uint32
+
+	// Send delivers a WorldState snapshot to this client. It is set by the
+	// transport when the session is registered.
+	Send func(WorldState)
+}
+
+// LastSequence returns the highest input sequence number processed for
+// this session.
+func (s *Session) LastSequence() uint32 {
+	return s.lastSequence
+}
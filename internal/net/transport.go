@@ -0,0 +1,71 @@
+package net
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	// Demos connect from the same process or localhost; there is no
+	// cross-origin browser client to protect against yet.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// ServeWS upgrades r to a WebSocket connection and pumps it for as long as
+// the client stays connected: every ClientMessage the client sends is
+// applied via HandleMessage, and every WorldState the Server broadcasts to
+// this session is written back as JSON. The connection's Session is
+// registered under the "id" query parameter, or a fresh UUID if the client
+// didn't supply one, and is unregistered when the connection closes.
+func (s *Server) ServeWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Error("websocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	outgoing := make(chan WorldState, 8)
+	session := s.Register(id, func(state WorldState) {
+		select {
+		case outgoing <- state:
+		default:
+			<-outgoing
+			outgoing <- state
+		}
+	})
+	defer s.Unregister(session)
+
+	reads := make(chan ClientMessage)
+	readErrs := make(chan error, 1)
+	go func() {
+		for {
+			var msg ClientMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				readErrs <- err
+				return
+			}
+			reads <- msg
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-reads:
+			s.HandleMessage(session, msg)
+		case <-readErrs:
+			return
+		case state := <-outgoing:
+			if err := conn.WriteJSON(state); err != nil {
+				return
+			}
+		}
+	}
+}
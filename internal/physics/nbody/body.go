@@ -0,0 +1,16 @@
+// Package nbody implements a Barnes-Hut octree gravity solver, letting
+// universe.gravity_movement_bh simulate far more bodies than the O(N^2)
+// per-sphere physics.AttractorForceField approach used by the other
+// universe demos.
+package nbody
+
+import "github.com/g3n/engine/math32"
+
+// Body is one point mass under simulation. Index order is significant:
+// Octree results are keyed by a body's position in the slice passed to
+// Build/Step, not by any identity carried in Body itself.
+type Body struct {
+	Position math32.Vector3
+	Velocity math32.Vector3
+	Mass     float32
+}
@@ -0,0 +1,156 @@
+package nbody
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/g3n/engine/math32"
+)
+
+// directForce computes the exact O(N^2) gravitational force on bodies[i]
+// with the same softening/G the Octree uses, for validating the
+// Barnes-Hut approximation against ground truth.
+func directForce(bodies []Body, i int, softening, g float32) math32.Vector3 {
+	var force math32.Vector3
+	for j := range bodies {
+		if j == i {
+			continue
+		}
+		r := bodies[j].Position
+		r.Sub(&bodies[i].Position)
+		distSq := r.LengthSq() + softening*softening
+		dist := math32.Sqrt(distSq)
+		scale := g * bodies[i].Mass * bodies[j].Mass / (distSq * dist)
+		r.MultiplyScalar(scale)
+		force.Add(&r)
+	}
+	return force
+}
+
+func randomBodies(n int, seed int64) []Body {
+	r := rand.New(rand.NewSource(seed))
+	bodies := make([]Body, n)
+	for i := range bodies {
+		bodies[i] = Body{
+			Position: *math32.NewVector3(r.Float32()*20-10, r.Float32()*20-10, r.Float32()*20-10),
+			Mass:     1 + r.Float32(),
+		}
+	}
+	return bodies
+}
+
+func TestOctreeForceOnMatchesDirectSumWithinTheta(t *testing.T) {
+	bodies := randomBodies(200, 1)
+	const softening, g = 0.1, 1.0
+
+	tree := NewOctree(0.5, softening, g)
+	tree.Build(bodies)
+	defer tree.Release()
+
+	for i := range bodies {
+		got := tree.ForceOn(i)
+		want := directForce(bodies, i, softening, g)
+
+		diff := got
+		diff.Sub(&want)
+		// theta=0.5 trades some accuracy for speed; this tolerance is
+		// generous enough to catch a broken traversal, not to pin down
+		// the exact approximation error.
+		if rel := diff.Length() / (want.Length() + 1e-6); rel > 0.15 {
+			t.Fatalf("body %d: ForceOn = %v, direct sum = %v (relative error %.3f)", i, got, want, rel)
+		}
+	}
+}
+
+func TestOctreeForceOnMatchesDirectSumExactlyAtTheta0(t *testing.T) {
+	bodies := randomBodies(50, 2)
+	const softening, g = 0.1, 1.0
+
+	tree := NewOctree(0, softening, g)
+	tree.Build(bodies)
+	defer tree.Release()
+
+	for i := range bodies {
+		got := tree.ForceOn(i)
+		want := directForce(bodies, i, softening, g)
+
+		diff := got
+		diff.Sub(&want)
+		if diff.Length() > 1e-3 {
+			t.Fatalf("body %d: ForceOn = %v, direct sum = %v", i, got, want)
+		}
+	}
+}
+
+func TestOctreeForceOnHandlesCoincidentBodiesPastMaxDepth(t *testing.T) {
+	// Two bodies at the exact same position always fall in the same
+	// octant, so insert can never split them apart: it recurses all the
+	// way to maxDepth and must bucket them into the leaf's overflow
+	// instead of creating child nodes.
+	bodies := []Body{
+		{Position: math32.Vector3{X: 0, Y: 0, Z: 0}, Mass: 1},
+		{Position: math32.Vector3{X: 0, Y: 0, Z: 0}, Mass: 1},
+		{Position: math32.Vector3{X: 10, Y: 10, Z: 10}, Mass: 1},
+	}
+	const softening, g = 0.1, 1.0
+
+	tree := NewOctree(0.5, softening, g)
+	tree.Build(bodies)
+	defer tree.Release()
+
+	for i := range bodies {
+		got := tree.ForceOn(i)
+		if math.IsNaN(float64(got.Length())) || math.IsInf(float64(got.Length()), 0) {
+			t.Fatalf("body %d: ForceOn = %v, want finite", i, got)
+		}
+	}
+
+	// The two coincident bodies are softened against each other and
+	// against the distant third body; neither should show the runaway
+	// magnitude a spurious self-attraction term would produce.
+	want := directForce(bodies, 2, softening, g)
+	got := tree.ForceOn(2)
+	diff := got
+	diff.Sub(&want)
+	if diff.Length() > 1e-3 {
+		t.Fatalf("body 2: ForceOn = %v, direct sum = %v", got, want)
+	}
+}
+
+func TestSimulationStepConservesMomentum(t *testing.T) {
+	bodies := randomBodies(64, 3)
+	sim := NewSimulation(bodies, 0.5, 0.1, 1.0)
+
+	momentum := func() math32.Vector3 {
+		var p math32.Vector3
+		for _, b := range sim.Bodies {
+			v := b.Velocity
+			v.MultiplyScalar(b.Mass)
+			p.Add(&v)
+		}
+		return p
+	}
+
+	before := momentum()
+	for i := 0; i < 10; i++ {
+		sim.Step(0.01)
+	}
+	after := momentum()
+
+	diff := after
+	diff.Sub(&before)
+	if math.IsNaN(float64(diff.Length())) || diff.Length() > 1e-2 {
+		t.Fatalf("momentum drifted from %v to %v over 10 steps", before, after)
+	}
+}
+
+func BenchmarkSimulationStep10000(b *testing.B) {
+	bodies := randomBodies(10000, 4)
+	sim := NewSimulation(bodies, 0.5, 0.1, 1.0)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sim.Step(0.01)
+	}
+}
@@ -0,0 +1,96 @@
+package nbody
+
+import "github.com/g3n/engine/math32"
+
+// maxDepth bounds recursion when two bodies sit at (or extremely near)
+// the same position, which would otherwise subdivide forever trying to
+// separate them into different octants.
+const maxDepth = 64
+
+// node is one cube of an Octree. A node with no children is a leaf: it
+// holds zero bodies (body == -1, overflow empty), exactly one body
+// (body >= 0), or - once two bodies land in the same cube at maxDepth
+// and can no longer be split into distinct octants - a bucket of
+// coincident bodies in overflow (body == -1, len(overflow) >= 2).
+// Internal nodes never carry a body themselves; mass and centerOfMass
+// are always the aggregate of every body in the subtree rooted here.
+type node struct {
+	center math32.Vector3
+	size   float32
+
+	mass         float32
+	centerOfMass math32.Vector3
+
+	body     int   // index into the Octree's bodies slice, or -1
+	overflow []int // extra bodies bucketed here past maxDepth
+	children [8]*node
+}
+
+func (n *node) isLeaf() bool {
+	return n.children[0] == nil && n.children[1] == nil && n.children[2] == nil && n.children[3] == nil &&
+		n.children[4] == nil && n.children[5] == nil && n.children[6] == nil && n.children[7] == nil
+}
+
+// holds reports whether leaf n directly represents body i, either as its
+// sole occupant or as a member of its maxDepth overflow bucket.
+func (n *node) holds(i int) bool {
+	if n.body == i {
+		return true
+	}
+	for _, j := range n.overflow {
+		if j == i {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *node) reset(center math32.Vector3, size float32) {
+	n.center = center
+	n.size = size
+	n.mass = 0
+	n.centerOfMass = math32.Vector3{}
+	n.body = -1
+	n.overflow = n.overflow[:0]
+	for i := range n.children {
+		n.children[i] = nil
+	}
+}
+
+// octantOf returns which of the node's 8 children pos falls into.
+func octantOf(center, pos math32.Vector3) int {
+	octant := 0
+	if pos.X >= center.X {
+		octant |= 1
+	}
+	if pos.Y >= center.Y {
+		octant |= 2
+	}
+	if pos.Z >= center.Z {
+		octant |= 4
+	}
+	return octant
+}
+
+// childCenter returns the center of the given octant of a node with the
+// given center and size.
+func childCenter(center math32.Vector3, size float32, octant int) math32.Vector3 {
+	q := size / 4
+	c := center
+	if octant&1 != 0 {
+		c.X += q
+	} else {
+		c.X -= q
+	}
+	if octant&2 != 0 {
+		c.Y += q
+	} else {
+		c.Y -= q
+	}
+	if octant&4 != 0 {
+		c.Z += q
+	} else {
+		c.Z -= q
+	}
+	return c
+}
@@ -0,0 +1,109 @@
+package nbody
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/g3n/engine/math32"
+)
+
+// Simulation advances a slice of Bodies under mutual gravity using a
+// Barnes-Hut octree for force evaluation and velocity-Verlet for
+// integration. It exists so universe.gravity_movement_bh can simulate
+// 10k+ spheres at interactive rates, which the O(N^2) per-sphere
+// physics.AttractorForceField approach used by the other universe demos
+// cannot do.
+type Simulation struct {
+	Bodies []Body
+
+	tree    *Octree
+	workers int
+}
+
+// NewSimulation creates a Simulation over bodies (retained, not copied)
+// with the given Barnes-Hut opening angle, softening factor and
+// gravitational constant. Force evaluation is split across
+// runtime.NumCPU() workers.
+func NewSimulation(bodies []Body, theta, softening, g float32) *Simulation {
+	return &Simulation{
+		Bodies:  bodies,
+		tree:    NewOctree(theta, softening, g),
+		workers: runtime.NumCPU(),
+	}
+}
+
+// Step advances the simulation by dt using velocity-Verlet: a half
+// kick from the forces at the start of the step, a drift, a tree
+// rebuild at the new positions, then a second half kick from the
+// forces at the end of the step.
+func (s *Simulation) Step(dt float32) {
+	s.tree.Build(s.Bodies)
+	forces := s.forces()
+	s.tree.Release()
+
+	half := dt * 0.5
+	for i := range s.Bodies {
+		b := &s.Bodies[i]
+		accel := forces[i]
+		accel.MultiplyScalar(1 / b.Mass)
+		accel.MultiplyScalar(half)
+		b.Velocity.Add(&accel)
+
+		drift := b.Velocity
+		drift.MultiplyScalar(dt)
+		b.Position.Add(&drift)
+	}
+
+	s.tree.Build(s.Bodies)
+	forces = s.forces()
+	s.tree.Release()
+
+	for i := range s.Bodies {
+		b := &s.Bodies[i]
+		accel := forces[i]
+		accel.MultiplyScalar(1 / b.Mass)
+		accel.MultiplyScalar(half)
+		b.Velocity.Add(&accel)
+	}
+}
+
+// forces evaluates ForceOn for every body in parallel, fanning the
+// range [0, len(Bodies)) out across s.workers goroutines.
+func (s *Simulation) forces() []math32.Vector3 {
+	n := len(s.Bodies)
+	out := make([]math32.Vector3, n)
+
+	workers := s.workers
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := range s.Bodies {
+			out[i] = s.tree.ForceOn(i)
+		}
+		return out
+	}
+
+	var wg sync.WaitGroup
+	chunk := (n + workers - 1) / workers
+	for w := 0; w < workers; w++ {
+		start := w * chunk
+		end := start + chunk
+		if start >= n {
+			break
+		}
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				out[i] = s.tree.ForceOn(i)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return out
+}
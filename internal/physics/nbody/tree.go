@@ -0,0 +1,198 @@
+package nbody
+
+import (
+	"sync"
+
+	"github.com/g3n/engine/math32"
+)
+
+// Octree is a Barnes-Hut octree built fresh over a set of bodies every
+// Step (positions move every step, so the tree can't be incrementally
+// updated). Nodes are drawn from a sync.Pool so repeated Build calls
+// reuse the previous frame's allocations instead of generating garbage
+// every step.
+type Octree struct {
+	Theta     float32 // opening angle; larger = faster, less accurate
+	Softening float32 // epsilon added to |r|^2 to avoid singularities
+	G         float32 // gravitational constant
+
+	pool sync.Pool
+	used []*node // nodes handed out by the pool during the current Build
+
+	root   *node
+	bodies []Body
+}
+
+// NewOctree creates an Octree with the given opening angle, softening
+// factor and gravitational constant.
+func NewOctree(theta, softening, g float32) *Octree {
+	t := &Octree{Theta: theta, Softening: softening, G: g}
+	t.pool.New = func() any { return new(node) }
+	return t
+}
+
+func (t *Octree) alloc(center math32.Vector3, size float32) *node {
+	n := t.pool.Get().(*node)
+	n.reset(center, size)
+	t.used = append(t.used, n)
+	return n
+}
+
+// Release returns every node allocated by the last Build back to the
+// pool. Call it once the previous step's force evaluation is done,
+// before the next Build, so node allocations are recycled across steps
+// instead of re-created from scratch every frame.
+func (t *Octree) Release() {
+	for _, n := range t.used {
+		t.pool.Put(n)
+	}
+	t.used = t.used[:0]
+	t.root = nil
+	t.bodies = nil
+}
+
+// Build computes the bounding cube of bodies and inserts them one at a
+// time into a fresh octree. bodies is retained (not copied) for the
+// lifetime of the tree; callers must not mutate it until Release.
+func (t *Octree) Build(bodies []Body) {
+	t.bodies = bodies
+	if len(bodies) == 0 {
+		t.root = nil
+		return
+	}
+
+	center, size := boundingCube(bodies)
+	t.root = t.alloc(center, size)
+	for i := range bodies {
+		t.insert(t.root, i, 0)
+	}
+}
+
+// boundingCube returns the center and edge length of the smallest cube
+// (axis-aligned, power-of-two padded slightly) containing every body.
+func boundingCube(bodies []Body) (math32.Vector3, float32) {
+	min, max := bodies[0].Position, bodies[0].Position
+	for _, b := range bodies[1:] {
+		min.Min(&b.Position)
+		max.Max(&b.Position)
+	}
+
+	center := min
+	center.Add(&max)
+	center.MultiplyScalar(0.5)
+
+	span := max
+	span.Sub(&min)
+	size := span.X
+	if span.Y > size {
+		size = span.Y
+	}
+	if span.Z > size {
+		size = span.Z
+	}
+	if size <= 0 {
+		size = 1
+	}
+	// Pad so bodies sitting exactly on the boundary still land inside.
+	return center, size * 1.01
+}
+
+// insert adds body index i into the subtree rooted at n, updating n's
+// aggregate mass and center of mass on the way back up.
+func (t *Octree) insert(n *node, i int, depth int) {
+	body := t.bodies[i]
+
+	if n.isLeaf() && n.body == -1 && len(n.overflow) == 0 {
+		n.body = i
+		n.mass = body.Mass
+		n.centerOfMass = body.Position
+		return
+	}
+
+	if n.isLeaf() {
+		if n.body != -1 && depth < maxDepth {
+			// Converting a one-body leaf into an internal node: both the
+			// existing occupant and the new body need to move down a level.
+			existing := n.body
+			n.body = -1
+			t.insertIntoChild(n, existing, depth)
+			t.insertIntoChild(n, i, depth)
+		} else {
+			// Either already a maxDepth overflow bucket, or a one-body
+			// leaf that has hit maxDepth and can no longer be split into
+			// distinct octants. Either way, bucket the body here instead
+			// of aggregating it into a node that would still (wrongly)
+			// report itself as a different body's leaf.
+			if n.body != -1 {
+				n.overflow = append(n.overflow, n.body)
+				n.body = -1
+			}
+			n.overflow = append(n.overflow, i)
+		}
+	} else {
+		t.insertIntoChild(n, i, depth)
+	}
+
+	mass := n.mass + body.Mass
+	n.centerOfMass.MultiplyScalar(n.mass)
+	weighted := body.Position
+	weighted.MultiplyScalar(body.Mass)
+	n.centerOfMass.Add(&weighted)
+	if mass > 0 {
+		n.centerOfMass.MultiplyScalar(1 / mass)
+	}
+	n.mass = mass
+}
+
+func (t *Octree) insertIntoChild(n *node, i int, depth int) {
+	octant := octantOf(n.center, t.bodies[i].Position)
+	child := n.children[octant]
+	if child == nil {
+		child = t.alloc(childCenter(n.center, n.size, octant), n.size/2)
+		n.children[octant] = child
+	}
+	t.insert(child, i, depth+1)
+}
+
+// ForceOn returns the net gravitational force on bodies[i] from every
+// other body, approximated via the Barnes-Hut criterion: a node is
+// treated as a single point mass at its center of mass whenever its
+// size/distance ratio is below Theta, otherwise its children are
+// visited individually.
+func (t *Octree) ForceOn(i int) math32.Vector3 {
+	if t.root == nil {
+		return math32.Vector3{}
+	}
+	var force math32.Vector3
+	t.accumulate(t.root, i, &force)
+	return force
+}
+
+func (t *Octree) accumulate(n *node, i int, force *math32.Vector3) {
+	if n == nil || n.mass == 0 {
+		return
+	}
+	if n.isLeaf() && n.holds(i) {
+		return // a body never attracts itself, nor anything bucketed with it
+	}
+
+	r := n.centerOfMass
+	r.Sub(&t.bodies[i].Position)
+	distSq := r.LengthSq() + t.Softening*t.Softening
+	dist := math32.Sqrt(distSq)
+
+	if n.isLeaf() || n.size/dist < t.Theta {
+		if dist == 0 {
+			return
+		}
+		// F = G * m1 * m2 * r / |r|^3, r = (other - self).
+		scale := t.G * t.bodies[i].Mass * n.mass / (distSq * dist)
+		r.MultiplyScalar(scale)
+		force.Add(&r)
+		return
+	}
+
+	for _, child := range n.children {
+		t.accumulate(child, i, force)
+	}
+}
@@ -0,0 +1,204 @@
+package replay
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/g3n/engine/math32"
+)
+
+// magic identifies a .univ file; version lets the format change later
+// without silently misreading an old file.
+var magic = [4]byte{'U', 'N', 'I', 'V'}
+
+const version = 1
+
+// Save writes rec to path as a .univ file. Frame 0 is stored as absolute
+// float32 positions/velocities; every later frame stores only the delta
+// from the previous frame, since a demo's bodies typically move a small
+// amount per Step relative to their absolute position.
+//
+// The body set is taken from rec.Frames[0] and assumed fixed for the
+// whole recording — Save returns an error if a later frame doesn't have
+// an exact match for that set. Demos that spawn bodies mid-recording
+// aren't supported yet.
+func Save(path string, rec *Recording) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	names := bodyNames(rec)
+	if int32(len(names)) != rec.Header.BodyCount {
+		return fmt.Errorf("replay: header.BodyCount=%d but first frame has %d bodies", rec.Header.BodyCount, len(names))
+	}
+
+	w := &errWriter{w: f}
+	w.write(magic)
+	w.write(uint8(version))
+	w.write(rec.Header)
+	w.write(uint32(len(rec.Frames)))
+	for _, name := range names {
+		w.writeString(name)
+	}
+
+	var prev Frame
+	for _, frame := range rec.Frames {
+		if err := checkSameBodies(names, frame); err != nil {
+			return err
+		}
+		for _, name := range names {
+			curr := frame[name]
+			if prev == nil {
+				w.write(curr.Position)
+				w.write(curr.Velocity)
+			} else {
+				w.write(delta(curr.Position, prev[name].Position))
+				w.write(delta(curr.Velocity, prev[name].Velocity))
+			}
+		}
+		prev = frame
+	}
+
+	return w.err
+}
+
+// Load reads a .univ file written by Save back into a Recording.
+func Load(path string) (*Recording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := &errReader{r: f}
+	var got [4]byte
+	r.read(&got)
+	if got != magic {
+		return nil, fmt.Errorf("replay: %s is not a .univ file", path)
+	}
+	var gotVersion uint8
+	r.read(&gotVersion)
+	if gotVersion != version {
+		return nil, fmt.Errorf("replay: %s has unsupported version %d", path, gotVersion)
+	}
+
+	rec := &Recording{}
+	r.read(&rec.Header)
+	var numFrames uint32
+	r.read(&numFrames)
+
+	names := make([]string, rec.Header.BodyCount)
+	for i := range names {
+		names[i] = r.readString()
+	}
+
+	rec.Frames = make([]Frame, numFrames)
+	prev := make(Frame, len(names))
+	for i := range rec.Frames {
+		frame := make(Frame, len(names))
+		for _, name := range names {
+			var pos, vel math32.Vector3
+			r.read(&pos)
+			r.read(&vel)
+			if i > 0 {
+				p := prev[name]
+				pos = add(pos, p.Position)
+				vel = add(vel, p.Velocity)
+			}
+			frame[name] = Snapshot{Position: pos, Velocity: vel}
+		}
+		rec.Frames[i] = frame
+		prev = frame
+	}
+
+	if r.err != nil && r.err != io.EOF {
+		return nil, r.err
+	}
+	return rec, nil
+}
+
+func bodyNames(rec *Recording) []string {
+	if len(rec.Frames) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(rec.Frames[0]))
+	for name := range rec.Frames[0] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func checkSameBodies(names []string, frame Frame) error {
+	if len(frame) != len(names) {
+		return fmt.Errorf("replay: frame has %d bodies, expected %d", len(frame), len(names))
+	}
+	for _, name := range names {
+		if _, ok := frame[name]; !ok {
+			return fmt.Errorf("replay: frame is missing body %q", name)
+		}
+	}
+	return nil
+}
+
+func delta(curr, prev math32.Vector3) math32.Vector3 {
+	d := curr
+	d.Sub(&prev)
+	return d
+}
+
+func add(d, prev math32.Vector3) math32.Vector3 {
+	sum := d
+	sum.Add(&prev)
+	return sum
+}
+
+// errWriter/errReader let Save/Load write a long sequence of fixed-size
+// fields without checking an error after every single one.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *errWriter) write(v any) {
+	if w.err != nil {
+		return
+	}
+	w.err = binary.Write(w.w, binary.BigEndian, v)
+}
+
+func (w *errWriter) writeString(s string) {
+	w.write(uint16(len(s)))
+	if w.err != nil {
+		return
+	}
+	_, w.err = io.WriteString(w.w, s)
+}
+
+type errReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *errReader) read(v any) {
+	if r.err != nil {
+		return
+	}
+	r.err = binary.Read(r.r, binary.BigEndian, v)
+}
+
+func (r *errReader) readString() string {
+	var length uint16
+	r.read(&length)
+	if r.err != nil {
+		return ""
+	}
+	buf := make([]byte, length)
+	_, r.err = io.ReadFull(r.r, buf)
+	return string(buf)
+}
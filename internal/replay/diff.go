@@ -0,0 +1,32 @@
+package replay
+
+// Diff compares two Recordings frame by frame and body by body, and
+// reports the first point where a's and b's positions disagree by more
+// than epsilon. It's meant for regression-testing one solver against
+// another (e.g. Barnes-Hut against the direct O(N^2) sum) by recording
+// the same scenario under both and diffing the results.
+//
+// diverged is false if every shared frame and body matched within
+// epsilon. Frames beyond the shorter Recording's length aren't compared.
+func Diff(a, b *Recording, epsilon float32) (frame int, body string, diverged bool) {
+	n := len(a.Frames)
+	if len(b.Frames) < n {
+		n = len(b.Frames)
+	}
+
+	for i := 0; i < n; i++ {
+		for name, snapA := range a.Frames[i] {
+			snapB, ok := b.Frames[i][name]
+			if !ok {
+				return i, name, true
+			}
+			d := snapA.Position
+			d.Sub(&snapB.Position)
+			if d.Length() > epsilon {
+				return i, name, true
+			}
+		}
+	}
+
+	return 0, "", false
+}
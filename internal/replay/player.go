@@ -0,0 +1,46 @@
+package replay
+
+// Player steps through a Recording's frames, forward or backward, for
+// scrubbing a paused demo.
+type Player struct {
+	rec *Recording
+	idx int
+}
+
+// NewPlayer starts a Player at the first frame of rec.
+func NewPlayer(rec *Recording) *Player {
+	return &Player{rec: rec}
+}
+
+// Len returns the number of frames in the underlying Recording.
+func (p *Player) Len() int {
+	return len(p.rec.Frames)
+}
+
+// Index returns the frame the Player is currently on.
+func (p *Player) Index() int {
+	return p.idx
+}
+
+// Frame returns the current frame's snapshot.
+func (p *Player) Frame() Frame {
+	return p.rec.Frames[p.idx]
+}
+
+// StepForward advances one frame and returns it, clamping at the last
+// frame instead of wrapping.
+func (p *Player) StepForward() Frame {
+	if p.idx < len(p.rec.Frames)-1 {
+		p.idx++
+	}
+	return p.Frame()
+}
+
+// StepBackward rewinds one frame and returns it, clamping at the first
+// frame instead of wrapping.
+func (p *Player) StepBackward() Frame {
+	if p.idx > 0 {
+		p.idx--
+	}
+	return p.Frame()
+}
@@ -0,0 +1,32 @@
+package replay
+
+// Recorder accumulates one Frame per Step while a demo is recording.
+// It holds everything in memory; call Save to flush it to disk once
+// recording stops.
+type Recorder struct {
+	header Header
+	frames []Frame
+}
+
+// NewRecorder starts a new recording with the given Header.
+func NewRecorder(header Header) *Recorder {
+	return &Recorder{header: header}
+}
+
+// Record appends frame as the next Step's snapshot. Callers must pass a
+// Frame per Step, in order — the delta compression Save applies assumes
+// consecutive frames are in fact consecutive ticks.
+func (r *Recorder) Record(frame Frame) {
+	r.frames = append(r.frames, frame)
+}
+
+// Len returns the number of frames recorded so far.
+func (r *Recorder) Len() int {
+	return len(r.frames)
+}
+
+// Recording returns the Recorder's contents as a Recording, ready to
+// Save or hand to Diff.
+func (r *Recorder) Recording() *Recording {
+	return &Recording{Header: r.header, Frames: r.frames}
+}
@@ -0,0 +1,36 @@
+// Package replay records a universe demo's per-Step physics state to a
+// compact on-disk format and plays it back deterministically, so a run
+// can be scrubbed frame-by-frame or diffed against another run (e.g. to
+// regression-test the Barnes-Hut solver against the direct one).
+package replay
+
+import "github.com/g3n/engine/math32"
+
+// Header describes the run a Recording came from: enough to recreate
+// the same starting conditions (seed, grid dimensions) and to sanity
+// check that a Recording being loaded matches what the caller expects.
+type Header struct {
+	Seed       int64
+	GridWidth  int32
+	GridHeight int32
+	GridDepth  int32
+	BodyCount  int32
+}
+
+// Snapshot is one body's physics state at a single frame.
+type Snapshot struct {
+	Position math32.Vector3
+	Velocity math32.Vector3
+}
+
+// Frame is every tracked body's Snapshot at a single Step, keyed by the
+// body name passed to physics.Simulation.AddBody.
+type Frame map[string]Snapshot
+
+// Recording is a full run: a Header plus one Frame per physics.Simulation
+// Step, in order. It's the in-memory, decoded form — Save/Load handle
+// the delta-compressed on-disk representation.
+type Recording struct {
+	Header Header
+	Frames []Frame
+}
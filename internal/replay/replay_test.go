@@ -0,0 +1,95 @@
+package replay
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/g3n/engine/math32"
+)
+
+func sampleRecording() *Recording {
+	header := Header{Seed: 42, GridWidth: 2, GridHeight: 1, GridDepth: 1, BodyCount: 2}
+	frames := []Frame{
+		{
+			"a": {Position: *math32.NewVector3(0, 0, 0), Velocity: *math32.NewVector3(1, 0, 0)},
+			"b": {Position: *math32.NewVector3(5, 0, 0), Velocity: *math32.NewVector3(-1, 0, 0)},
+		},
+		{
+			"a": {Position: *math32.NewVector3(1, 0, 0), Velocity: *math32.NewVector3(1, 0.5, 0)},
+			"b": {Position: *math32.NewVector3(4, 0, 0), Velocity: *math32.NewVector3(-1, 0, 0)},
+		},
+		{
+			"a": {Position: *math32.NewVector3(2, 0.5, 0), Velocity: *math32.NewVector3(1, 0.5, 0)},
+			"b": {Position: *math32.NewVector3(3, 0, 0), Velocity: *math32.NewVector3(-1, 0, 0)},
+		},
+	}
+	return &Recording{Header: header, Frames: frames}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	rec := sampleRecording()
+	path := filepath.Join(t.TempDir(), "run.univ")
+
+	if err := Save(path, rec); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got.Header != rec.Header {
+		t.Fatalf("Header = %+v; want %+v", got.Header, rec.Header)
+	}
+	if len(got.Frames) != len(rec.Frames) {
+		t.Fatalf("len(Frames) = %d; want %d", len(got.Frames), len(rec.Frames))
+	}
+	for i, frame := range rec.Frames {
+		for name, want := range frame {
+			got := got.Frames[i][name]
+			if got.Position.DistanceTo(&want.Position) > 1e-5 {
+				t.Fatalf("frame %d body %q Position = %v; want %v", i, name, got.Position, want.Position)
+			}
+			if got.Velocity.DistanceTo(&want.Velocity) > 1e-5 {
+				t.Fatalf("frame %d body %q Velocity = %v; want %v", i, name, got.Velocity, want.Velocity)
+			}
+		}
+	}
+}
+
+func TestPlayerStepForwardAndBackwardClamp(t *testing.T) {
+	p := NewPlayer(sampleRecording())
+
+	if p.Index() != 0 {
+		t.Fatalf("Index() = %d; want 0", p.Index())
+	}
+	p.StepBackward()
+	if p.Index() != 0 {
+		t.Fatalf("StepBackward at frame 0 moved to %d; want clamped at 0", p.Index())
+	}
+
+	p.StepForward()
+	p.StepForward()
+	p.StepForward() // one past the last frame; should clamp
+	if p.Index() != 2 {
+		t.Fatalf("Index() = %d; want clamped at 2", p.Index())
+	}
+}
+
+func TestDiffFindsFirstDivergence(t *testing.T) {
+	a := sampleRecording()
+	b := sampleRecording()
+
+	if _, _, diverged := Diff(a, b, 1e-4); diverged {
+		t.Fatal("Diff reported divergence between identical recordings")
+	}
+
+	bumped := b.Frames[2]["b"]
+	bumped.Position.X += 1
+	b.Frames[2]["b"] = bumped
+
+	frame, body, diverged := Diff(a, b, 1e-4)
+	if !diverged || frame != 2 || body != "b" {
+		t.Fatalf("Diff = (%d, %q, %v); want (2, \"b\", true)", frame, body, diverged)
+	}
+}
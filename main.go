@@ -55,14 +55,20 @@ type ClientMessage struct {
 }
 
 const (
-	EARTH_RADIUS     = 6371000 // meters
-	GRAVITY          = 9.81
-	WALK_SPEED       = 500.0 // meters per second
-	SWIM_SPEED       = 2.0
-	CLIMB_SPEED      = 1.5
-	MAX_DIVE_TIME    = 30.0 // seconds
-	TICK_RATE        = 1    // updates per second
+	EARTH_RADIUS  = 6371000 // meters
+	GRAVITY       = 9.81
+	WALK_SPEED    = 500.0 // meters per second
+	SWIM_SPEED    = 2.0
+	CLIMB_SPEED   = 1.5
+	MAX_DIVE_TIME = 30.0 // seconds
+
+	// TICK_RATE is how often player physics is integrated. It is kept well
+	// above the rate state is sent out (SEND_RATE) so movement stays stable
+	// even when the network can't keep up; see internal/net for the same
+	// split applied to the spheres/attractors demo world.
+	TICK_RATE        = 60 // updates per second
 	PHYSICS_TIMESTEP = 1.0 / float64(TICK_RATE)
+	SEND_RATE        = 20 // world-state broadcasts per second
 )
 
 var (
@@ -137,19 +143,28 @@ func handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	// Game loop for this player
-	ticker := time.NewTicker(time.Second / TICK_RATE)
-	for range ticker.C {
-		playersMutex.Lock()
-		updatePlayerPhysics(player)
+	// Physics runs at TICK_RATE regardless of how often we can afford to
+	// serialize and push a snapshot down the wire.
+	physicsTicker := time.NewTicker(time.Second / TICK_RATE)
+	defer physicsTicker.Stop()
+	sendTicker := time.NewTicker(time.Second / SEND_RATE)
+	defer sendTicker.Stop()
 
-		// Send world state to client
-		worldState := getWorldState()
-		if err := conn.WriteJSON(worldState); err != nil {
+	for {
+		select {
+		case <-physicsTicker.C:
+			playersMutex.Lock()
+			updatePlayerPhysics(player)
+			playersMutex.Unlock()
+		case <-sendTicker.C:
+			playersMutex.Lock()
+			worldState := getWorldState()
+			err := conn.WriteJSON(worldState)
 			playersMutex.Unlock()
-			return
+			if err != nil {
+				return
+			}
 		}
-		playersMutex.Unlock()
 	}
 }
 